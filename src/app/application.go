@@ -1,18 +1,116 @@
 package app
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/dleonsal/beers-api/src/configs"
 	"github.com/dleonsal/beers-api/src/infrastructure/logger"
 	"github.com/gin-gonic/gin"
 )
 
-func StartApplication() {
+// StartApplication loads the config of configPath, or of APP_ENV when it is
+// empty, and serves the API until it fails or gets SIGINT or SIGTERM.
+func StartApplication(configPath string) error {
 	logger.Log.Info("Starting Application")
+	config, err := configs.NewConfig(configPath, configs.NewSecretResolver())
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Info(fmt.Sprintf("Config loaded: %s", config))
+
+	handlers, closers, err := wireDependencies(config)
+	if err != nil {
+		return err
+	}
+
 	router := gin.Default()
-	config := configs.NewConfig()
-	handlers := wireDependencies(config)
 
 	mapRoutes(router, handlers)
 
-	router.Run(":" + config.Port)
+	server := newHTTPServer(config, router)
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		closeAll(closers)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return serve(ctx, server, listener, milliseconds(config.HTTPServerConfig.ShutdownTimeoutMilliseconds), closers)
+}
+
+func newHTTPServer(config *configs.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + config.Port,
+		Handler:           handler,
+		ReadTimeout:       milliseconds(config.HTTPServerConfig.ReadTimeoutMilliseconds),
+		ReadHeaderTimeout: milliseconds(config.HTTPServerConfig.ReadHeaderTimeoutMilliseconds),
+		WriteTimeout:      milliseconds(config.HTTPServerConfig.WriteTimeoutMilliseconds),
+		IdleTimeout:       milliseconds(config.HTTPServerConfig.IdleTimeoutMilliseconds),
+	}
+}
+
+// serve runs the server on listener until ctx is done, then stops accepting
+// connections and waits for the in-flight requests up to shutdownTimeout. The
+// closers run once the server stops, after the requests are drained.
+func serve(ctx context.Context, server *http.Server, listener net.Listener, shutdownTimeout time.Duration, closers []io.Closer) error {
+	defer closeAll(closers)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Log.Info("Shutting down application")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Error(fmt.Sprintf("requests still in flight after the shutdown timeout of %s, closing their connections", shutdownTimeout))
+		server.Close()
+		return fmt.Errorf("error trying to shut down the server: %s", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	logger.Log.Info("Server stopped")
+	return nil
+}
+
+// closeAll closes the resources in order, logging the ones that fail.
+func closeAll(closers []io.Closer) {
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to close resource: %s", err))
+		}
+	}
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+func milliseconds(value int) time.Duration {
+	return time.Duration(value) * time.Millisecond
 }
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Serve_WhenContextIsCanceledWithRequestInFlight_ThenDrainRequestBeforeClosing(t *testing.T) {
+	events := new(shutdownEvents)
+	started := make(chan struct{})
+	server, listener := givenServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		events.add("request done")
+		w.Write([]byte("done"))
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve(ctx, server, listener, 5*time.Second, []io.Closer{events.closer("db closed")})
+	}()
+
+	responseBody := make(chan string, 1)
+	go func() {
+		response, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responseBody <- err.Error()
+			return
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		responseBody <- string(body)
+	}()
+	<-started
+	cancel()
+
+	assert.Equal(t, "done", <-responseBody)
+	assert.Nil(t, <-serveErr)
+	assert.Equal(t, []string{"request done", "db closed"}, events.list())
+}
+
+func Test_Serve_WhenRequestOutlivesShutdownTimeout_ThenCloseConnectionsAndReturnError(t *testing.T) {
+	events := new(shutdownEvents)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server, listener := givenServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve(ctx, server, listener, 50*time.Millisecond, []io.Closer{events.closer("db closed")})
+	}()
+
+	requestErr := make(chan error, 1)
+	go func() {
+		response, err := http.Get("http://" + listener.Addr().String())
+		if err == nil {
+			response.Body.Close()
+		}
+		requestErr <- err
+	}()
+	<-started
+	cancel()
+
+	assert.EqualError(t, <-serveErr, "error trying to shut down the server: context deadline exceeded")
+	assert.NotNil(t, <-requestErr)
+	assert.Equal(t, []string{"db closed"}, events.list())
+}
+
+func Test_Serve_WhenServerFails_ThenCloseAndReturnError(t *testing.T) {
+	events := new(shutdownEvents)
+	server, listener := givenServer(t, http.NotFoundHandler())
+	listener.Close()
+
+	err := serve(context.Background(), server, listener, time.Second, []io.Closer{events.closer("db closed")})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"db closed"}, events.list())
+}
+
+func givenServer(t *testing.T, handler http.Handler) (*http.Server, net.Listener) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &http.Server{Handler: handler}, listener
+}
+
+type shutdownEvents struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *shutdownEvents) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *shutdownEvents) list() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string{}, e.events...)
+}
+
+func (e *shutdownEvents) closer(event string) io.Closer {
+	return closerFunc(func() error {
+		e.add(event)
+		return nil
+	})
+}
@@ -1,8 +1,10 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/dleonsal/beers-api/src/configs"
@@ -14,33 +16,222 @@ import (
 )
 
 const (
-	mysqlUsersUsername = "mysql_username"
-	mysqlUsersPassword = "mysql_password"
-	mysqlUsersHost     = "mysql_host"
-	mysqlUsersSchema   = "mysql_schema"
+	defaultHealthCheckTimeout       = 2 * time.Second
+	defaultMaxImportBodyBytes int64 = 10 << 20
 )
 
-var (
-	username = os.Getenv(mysqlUsersUsername)
-	password = os.Getenv(mysqlUsersPassword)
-	host     = os.Getenv(mysqlUsersHost)
-	schema   = os.Getenv(mysqlUsersSchema)
-)
+// wireDependencies returns the handlers along with the resources to close once
+// the server stops, in closing order.
+func wireDependencies(config *configs.Config) (*handlerContainer, []io.Closer, error) {
+	repositories, err := newRepositories(&config.DBConfig)
+	if err != nil {
+		return nil, nil, err
+	}
 
-func wireDependencies(config *configs.Config) *handlerContainer {
-	client := db.NewMySqlDB(&config.DBConfig)
-	beerRepository := repository.NewMySqlBeerRepository(client)
+	beerRepository := repositories.beerRepository
+	pricingRuleRepository := repositories.pricingRuleRepository
+	couponRepository := repositories.couponRepository
+	taxRuleRepository := repositories.taxRuleRepository
+	packRepository := repositories.packRepository
 	httpClient := &http.Client{
 		Timeout: time.Duration(config.HTTPClientTimeoutMilliseconds) * time.Millisecond,
 	}
 
-	currencyConverterClient := providers.NewCurrencyConverterRestClient(
+	currencyConverterClient, err := newCurrencyConverterClient(config, httpClient)
+	if err != nil {
+		closeAll(repositories.closers)
+		return nil, nil, err
+	}
+
+	beerService := services.NewBeerService(beerRepository, pricingRuleRepository, couponRepository, taxRuleRepository, packRepository, currencyConverterClient)
+	beerHandler := handler.NewBeerHandler(beerService, maxImportBodyBytes(&config.BeerImportConfig))
+	currencyHandler := handler.NewCurrencyHandler()
+	pricingRuleService := services.NewPricingRuleService(pricingRuleRepository, beerRepository)
+	pricingRuleHandler := handler.NewPricingRuleHandler(pricingRuleService)
+	couponService := services.NewCouponService(couponRepository, beerRepository)
+	couponHandler := handler.NewCouponHandler(couponService)
+	taxRuleService := services.NewTaxRuleService(taxRuleRepository)
+	taxRuleHandler := handler.NewTaxRuleHandler(taxRuleService)
+	packService := services.NewPackService(packRepository, beerRepository)
+	packHandler := handler.NewPackHandler(packService)
+	healthService := newHealthService(&config.HealthConfig, repositories.healthChecks, currencyConverterClient)
+	healthHandler := handler.NewHealthHandler(healthService)
+
+	closers := append(repositories.closers, closerFunc(func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	}))
+
+	return newHandlerContainer(beerHandler, currencyHandler, pricingRuleHandler, couponHandler, taxRuleHandler, packHandler, healthHandler), closers, nil
+}
+
+type repositoryContainer struct {
+	beerRepository        services.BeerRepository
+	pricingRuleRepository services.PricingRuleRepository
+	couponRepository      services.CouponRepository
+	taxRuleRepository     services.TaxRuleRepository
+	packRepository        services.PackRepository
+	healthChecks          []services.HealthCheck
+	closers               []io.Closer
+}
+
+func newRepositories(config *configs.DBConfig) (*repositoryContainer, error) {
+	if config.DriverName == configs.MemoryDriverName {
+		store := repository.NewMemoryStore()
+
+		return &repositoryContainer{
+			beerRepository:        repository.NewMemoryBeerRepository(store),
+			pricingRuleRepository: repository.NewMemoryPricingRuleRepository(store),
+			couponRepository:      repository.NewMemoryCouponRepository(store),
+			taxRuleRepository:     repository.NewMemoryTaxRuleRepository(store),
+			packRepository:        repository.NewMemoryPackRepository(store),
+		}, nil
+	}
+
+	dialect, err := db.DialectFor(config.DriverName)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := db.NewSqlDB(config, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateUp(client, dialect); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &repositoryContainer{
+		beerRepository:        repository.NewSqlBeerRepository(client, dialect),
+		pricingRuleRepository: repository.NewSqlPricingRuleRepository(client, dialect),
+		couponRepository:      repository.NewSqlCouponRepository(client, dialect),
+		taxRuleRepository:     repository.NewSqlTaxRuleRepository(client, dialect),
+		packRepository:        repository.NewSqlPackRepository(client, dialect),
+		healthChecks: []services.HealthCheck{{
+			Name:     config.DriverName,
+			Critical: true,
+			Check:    client.PingContext,
+		}},
+		closers: []io.Closer{client},
+	}, nil
+}
+
+// newHealthService checks the database along with, when enabled, the currency
+// converter, probed with a USD to EUR rate.
+func newHealthService(config *configs.HealthConfig, healthChecks []services.HealthCheck, currencyConverterClient services.CurrencyConverterClient) handler.HealthService {
+	if config.CheckCurrencyConverter {
+		healthChecks = append(healthChecks, services.HealthCheck{
+			Name: "currency-converter",
+			Check: func(context.Context) error {
+				if _, restErr := currencyConverterClient.GetExchangeRate("USD", "EUR"); restErr != nil {
+					return fmt.Errorf("%s", restErr.Message)
+				}
+
+				return nil
+			},
+		})
+	}
+
+	timeout := time.Duration(config.CheckTimeoutMilliseconds) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	return services.NewHealthService(timeout, healthChecks...)
+}
+
+func newCurrencyConverterClient(config *configs.Config, httpClient providers.HTTPClient) (services.CurrencyConverterClient, error) {
+	providerNames := config.CurrencyConverterProviderNames()
+	exchangeRateProviders := make([]providers.ExchangeRateProvider, 0, len(providerNames))
+	for _, providerName := range providerNames {
+		exchangeRateProvider, err := newExchangeRateProvider(config, providerName, httpClient)
+		if err != nil {
+			return nil, err
+		}
+
+		exchangeRateProviders = append(exchangeRateProviders, exchangeRateProvider)
+	}
+
+	if len(exchangeRateProviders) == 1 {
+		return exchangeRateProviders[0], nil
+	}
+
+	return providers.NewFailoverCurrencyConverterClient(exchangeRateProviders...), nil
+}
+
+func newExchangeRateProvider(config *configs.Config, providerName string, httpClient providers.HTTPClient) (providers.ExchangeRateProvider, error) {
+	switch providerName {
+	case configs.RestCurrencyConverterProvider:
+		return newRestCurrencyConverterClient(&config.CurrencyConverterRestClientConfig, httpClient), nil
+	case configs.ECBCurrencyConverterProvider:
+		return newECBCurrencyConverterClient(&config.ECBCurrencyConverterClientConfig, httpClient), nil
+	case configs.StaticCurrencyConverterProvider:
+		return newStaticCurrencyConverterClient(&config.StaticCurrencyConverterClientConfig)
+	default:
+		return nil, fmt.Errorf("unknown currency converter provider: %s", providerName)
+	}
+}
+
+func newStaticCurrencyConverterClient(config *configs.StaticCurrencyConverterClientConfig) (providers.ExchangeRateProvider, error) {
+	rates, err := providers.LoadStaticRates(config.RatesFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to load static rates from %s: %s", config.RatesFilePath, err)
+	}
+
+	return providers.NewStaticCurrencyConverterClient(rates), nil
+}
+
+func newECBCurrencyConverterClient(config *configs.ECBCurrencyConverterClientConfig, httpClient providers.HTTPClient) providers.ExchangeRateProvider {
+	ecbClient := providers.NewECBCurrencyConverterClient(
 		httpClient,
-		config.CurrencyConverterRestClientConfig.BaseURL,
-		time.Duration(config.CurrencyConverterRestClientConfig.RequestTimeoutMilliseconds)*time.Millisecond,
-		os.Getenv(config.CurrencyConverterRestClientConfig.XAPIKeyEnv))
-	beerService := services.NewBeerService(beerRepository, currencyConverterClient)
-	beerHandler := handler.NewBeerHandler(beerService)
+		config.FeedURL,
+		time.Duration(config.RequestTimeoutMilliseconds)*time.Millisecond)
+
+	return withCache(ecbClient, config.CacheTTLMilliseconds)
+}
+
+func newRestCurrencyConverterClient(config *configs.CurrencyConverterRestClientConfig, httpClient providers.HTTPClient) providers.ExchangeRateProvider {
+	restClient := providers.NewCurrencyConverterRestClient(
+		httpClient,
+		config.BaseURL,
+		time.Duration(config.RequestTimeoutMilliseconds)*time.Millisecond,
+		config.XAPIKey,
+		providers.RetryPolicy{
+			MaxAttempts:    config.RetryMaxAttempts,
+			InitialBackoff: time.Duration(config.RetryInitialBackoffMilliseconds) * time.Millisecond,
+			MaxBackoff:     time.Duration(config.RetryMaxBackoffMilliseconds) * time.Millisecond,
+		},
+		newCircuitBreaker(config))
+
+	return withCache(restClient, config.CacheTTLMilliseconds)
+}
+
+func newCircuitBreaker(config *configs.CurrencyConverterRestClientConfig) *providers.CircuitBreaker {
+	if config.CircuitBreakerFailureThreshold <= 0 {
+		return nil
+	}
+
+	return providers.NewCircuitBreaker(
+		config.CircuitBreakerFailureThreshold,
+		time.Duration(config.CircuitBreakerOpenTimeoutMilliseconds)*time.Millisecond)
+}
+
+func withCache(provider providers.ExchangeRateProvider, cacheTTLMilliseconds int) providers.ExchangeRateProvider {
+	if cacheTTLMilliseconds <= 0 {
+		return provider
+	}
+
+	return providers.NewCachedCurrencyConverterClient(
+		provider,
+		time.Duration(cacheTTLMilliseconds)*time.Millisecond)
+}
+
+func maxImportBodyBytes(config *configs.BeerImportConfig) int64 {
+	if config.MaxBodyBytes <= 0 {
+		return defaultMaxImportBodyBytes
+	}
 
-	return newHandlerContainer(beerHandler)
+	return int64(config.MaxBodyBytes)
 }
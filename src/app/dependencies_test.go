@@ -0,0 +1,45 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WireDependencies_WhenStaticRatesFileIsMissing_ThenReturnError(t *testing.T) {
+	ratesFilePath := filepath.Join(t.TempDir(), "rates.yaml")
+	config := &configs.Config{
+		DBConfig:                   configs.DBConfig{DriverName: configs.MemoryDriverName},
+		CurrencyConverterProviders: []string{configs.StaticCurrencyConverterProvider},
+		StaticCurrencyConverterClientConfig: configs.StaticCurrencyConverterClientConfig{
+			RatesFilePath: ratesFilePath,
+		},
+	}
+
+	handlers, closers, err := wireDependencies(config)
+
+	assert.Nil(t, handlers)
+	assert.Nil(t, closers)
+	assert.Contains(t, err.Error(), "error trying to load static rates from "+ratesFilePath)
+}
+
+func Test_NewRepositories_WhenDatabaseIsUnreachable_ThenReturnError(t *testing.T) {
+	config := &configs.DBConfig{
+		DriverName: configs.SQLiteDriverName,
+		DBName:     filepath.Join(t.TempDir(), "missing", "beers.db"),
+	}
+
+	repositories, err := newRepositories(config)
+
+	assert.Nil(t, repositories)
+	assert.Contains(t, err.Error(), "error trying to connect to sqlite database")
+}
+
+func Test_NewRepositories_WhenDriverIsUnknown_ThenReturnError(t *testing.T) {
+	repositories, err := newRepositories(&configs.DBConfig{DriverName: "oracle"})
+
+	assert.Nil(t, repositories)
+	assert.EqualError(t, err, "unknown sql driver: oracle")
+}
@@ -9,14 +9,82 @@ type beerHandler interface {
 	HandleGetByID(c *gin.Context)
 	HandleGetBoxPrice(c *gin.Context)
 	HandleCreate(c *gin.Context)
+	HandleImport(c *gin.Context)
+	HandleExport(c *gin.Context)
+	HandleUpdate(c *gin.Context)
+	HandlePatch(c *gin.Context)
+	HandleDelete(c *gin.Context)
+}
+
+type currencyHandler interface {
+	HandleList(c *gin.Context)
+}
+
+type pricingRuleHandler interface {
+	HandleList(c *gin.Context)
+	HandleGetByID(c *gin.Context)
+	HandleCreate(c *gin.Context)
+	HandleUpdate(c *gin.Context)
+	HandleDelete(c *gin.Context)
+}
+
+type couponHandler interface {
+	HandleList(c *gin.Context)
+	HandleGetByID(c *gin.Context)
+	HandleCreate(c *gin.Context)
+	HandleUpdate(c *gin.Context)
+	HandleDelete(c *gin.Context)
+	HandleRedeem(c *gin.Context)
+}
+
+type taxRuleHandler interface {
+	HandleList(c *gin.Context)
+	HandleGetByID(c *gin.Context)
+	HandleCreate(c *gin.Context)
+	HandleUpdate(c *gin.Context)
+	HandleDelete(c *gin.Context)
+}
+
+type packHandler interface {
+	HandleList(c *gin.Context)
+	HandleListByBeer(c *gin.Context)
+	HandleGetByID(c *gin.Context)
+	HandleCreate(c *gin.Context)
+	HandleUpdate(c *gin.Context)
+	HandleDelete(c *gin.Context)
+}
+
+type healthHandler interface {
+	HandleLive(c *gin.Context)
+	HandleReady(c *gin.Context)
 }
 
 type handlerContainer struct {
-	beerHandler beerHandler
+	beerHandler        beerHandler
+	currencyHandler    currencyHandler
+	pricingRuleHandler pricingRuleHandler
+	couponHandler      couponHandler
+	taxRuleHandler     taxRuleHandler
+	packHandler        packHandler
+	healthHandler      healthHandler
 }
 
-func newHandlerContainer(beerHandler beerHandler) *handlerContainer {
+func newHandlerContainer(
+	beerHandler beerHandler,
+	currencyHandler currencyHandler,
+	pricingRuleHandler pricingRuleHandler,
+	couponHandler couponHandler,
+	taxRuleHandler taxRuleHandler,
+	packHandler packHandler,
+	healthHandler healthHandler,
+) *handlerContainer {
 	return &handlerContainer{
-		beerHandler: beerHandler,
+		beerHandler:        beerHandler,
+		currencyHandler:    currencyHandler,
+		pricingRuleHandler: pricingRuleHandler,
+		couponHandler:      couponHandler,
+		taxRuleHandler:     taxRuleHandler,
+		packHandler:        packHandler,
+		healthHandler:      healthHandler,
 	}
 }
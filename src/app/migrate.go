@@ -0,0 +1,128 @@
+package app
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const migrateUsage = "usage: beers-api migrate up | down [steps] | status"
+
+// RunMigrate runs the migrate subcommand with its args, on the database of the
+// config of configPath, and returns the exit code of the process.
+func RunMigrate(configPath string, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	config, err := configs.NewConfig(configPath, configs.NewSecretResolver())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	dialect, err := db.DialectFor(config.DBConfig.DriverName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrations need a sql database: %s\n", err)
+		return 1
+	}
+
+	client, err := db.NewSqlDB(&config.DBConfig, dialect)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer client.Close()
+
+	migrator, err := newMigrator(client, dialect)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := runMigrateCommand(migrator, args, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func runMigrateCommand(migrator *db.Migrator, args []string, out io.Writer) error {
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			value, err := strconv.Atoi(args[1])
+			if err != nil || value <= 0 {
+				return fmt.Errorf("steps should be a positive number\n%s", migrateUsage)
+			}
+			steps = value
+		}
+
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%s\t%s\n", status.Migration.Version, status.Migration.Name, appliedAt)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command: %s\n%s", args[0], migrateUsage)
+	}
+}
+
+// migrateUp applies the pending migrations when the application starts.
+func migrateUp(client *sql.DB, dialect db.Dialect) error {
+	migrator, err := newMigrator(client, dialect)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up()
+	for _, migration := range applied {
+		logger.Log.Info(fmt.Sprintf("applied migration %04d_%s", migration.Version, migration.Name))
+	}
+
+	return err
+}
+
+func newMigrator(client *sql.DB, dialect db.Dialect) (*db.Migrator, error) {
+	migrations, err := db.EmbeddedMigrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.NewMigrator(client, dialect, migrations), nil
+}
@@ -6,8 +6,37 @@ import (
 
 func mapRoutes(router *gin.Engine, handlers *handlerContainer) {
 	router.GET("/beers", handlers.beerHandler.HandleList)
+	router.GET("/beers/export", handlers.beerHandler.HandleExport)
 	router.GET("/beers/:beer_id", handlers.beerHandler.HandleGetByID)
 	router.GET("/beers/:beer_id/boxprice", handlers.beerHandler.HandleGetBoxPrice)
+	router.GET("/beers/:beer_id/packs", handlers.packHandler.HandleListByBeer)
 	router.POST("/beers", handlers.beerHandler.HandleCreate)
-
+	router.POST("/beers/import", handlers.beerHandler.HandleImport)
+	router.PUT("/beers/:beer_id", handlers.beerHandler.HandleUpdate)
+	router.PATCH("/beers/:beer_id", handlers.beerHandler.HandlePatch)
+	router.DELETE("/beers/:beer_id", handlers.beerHandler.HandleDelete)
+	router.GET("/currencies", handlers.currencyHandler.HandleList)
+	router.GET("/pricing-rules", handlers.pricingRuleHandler.HandleList)
+	router.GET("/pricing-rules/:rule_id", handlers.pricingRuleHandler.HandleGetByID)
+	router.POST("/pricing-rules", handlers.pricingRuleHandler.HandleCreate)
+	router.PUT("/pricing-rules/:rule_id", handlers.pricingRuleHandler.HandleUpdate)
+	router.DELETE("/pricing-rules/:rule_id", handlers.pricingRuleHandler.HandleDelete)
+	router.GET("/coupons", handlers.couponHandler.HandleList)
+	router.GET("/coupons/:coupon_id", handlers.couponHandler.HandleGetByID)
+	router.POST("/coupons", handlers.couponHandler.HandleCreate)
+	router.PUT("/coupons/:coupon_id", handlers.couponHandler.HandleUpdate)
+	router.DELETE("/coupons/:coupon_id", handlers.couponHandler.HandleDelete)
+	router.POST("/coupons/:coupon_id/redemptions", handlers.couponHandler.HandleRedeem)
+	router.GET("/tax-rules", handlers.taxRuleHandler.HandleList)
+	router.GET("/tax-rules/:rule_id", handlers.taxRuleHandler.HandleGetByID)
+	router.POST("/tax-rules", handlers.taxRuleHandler.HandleCreate)
+	router.PUT("/tax-rules/:rule_id", handlers.taxRuleHandler.HandleUpdate)
+	router.DELETE("/tax-rules/:rule_id", handlers.taxRuleHandler.HandleDelete)
+	router.GET("/packs", handlers.packHandler.HandleList)
+	router.GET("/packs/:pack_id", handlers.packHandler.HandleGetByID)
+	router.POST("/packs", handlers.packHandler.HandleCreate)
+	router.PUT("/packs/:pack_id", handlers.packHandler.HandleUpdate)
+	router.DELETE("/packs/:pack_id", handlers.packHandler.HandleDelete)
+	router.GET("/health/live", handlers.healthHandler.HandleLive)
+	router.GET("/health/ready", handlers.healthHandler.HandleReady)
 }
@@ -1,42 +1,229 @@
 package configs
 
 import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/dleonsal/beers-api/src/configs/environment"
 	"gopkg.in/yaml.v2"
 )
 
+// Exchange rate providers allowed in CurrencyConverterProviders. They are tried
+// in the configured order, falling back to the next one when a provider fails.
+const (
+	RestCurrencyConverterProvider   = "rest"
+	ECBCurrencyConverterProvider    = "ecb"
+	StaticCurrencyConverterProvider = "static"
+)
+
+// Database drivers allowed in DBConfig.DriverName. The memory driver keeps the
+// data in the process, so the API can run without a database. The sqlite one
+// uses DBName as the path of the database file.
+const (
+	MySQLDriverName    = "mysql"
+	PostgresDriverName = "postgres"
+	SQLiteDriverName   = "sqlite"
+	MemoryDriverName   = "memory"
+)
+
 type Config struct {
-	Port                              string                            `yaml:"Port"`
-	DBConfig                          DBConfig                          `yaml:"DBConfig"`
-	CurrencyConverterRestClientConfig CurrencyConverterRestClientConfig `yaml:"CurrencyConverterRestClientConfig"`
-	HTTPClientTimeoutMilliseconds     int                               `yaml:"HTTPClientTimeoutMilliseconds"`
+	Port                                string                              `yaml:"Port"`
+	HTTPServerConfig                    HTTPServerConfig                    `yaml:"HTTPServerConfig"`
+	HealthConfig                        HealthConfig                        `yaml:"HealthConfig"`
+	BeerImportConfig                    BeerImportConfig                    `yaml:"BeerImportConfig"`
+	DBConfig                            DBConfig                            `yaml:"DBConfig"`
+	CurrencyConverterProviders          []string                            `yaml:"CurrencyConverterProviders"`
+	CurrencyConverterRestClientConfig   CurrencyConverterRestClientConfig   `yaml:"CurrencyConverterRestClientConfig"`
+	ECBCurrencyConverterClientConfig    ECBCurrencyConverterClientConfig    `yaml:"ECBCurrencyConverterClientConfig"`
+	StaticCurrencyConverterClientConfig StaticCurrencyConverterClientConfig `yaml:"StaticCurrencyConverterClientConfig"`
+	HTTPClientTimeoutMilliseconds       int                                 `yaml:"HTTPClientTimeoutMilliseconds"`
+}
+
+// HTTPServerConfig holds the timeouts of the server, where zero means no
+// timeout. WriteTimeout bounds the whole response, so it must leave time for
+// the beer export. In-flight requests are drained for up to
+// ShutdownTimeoutMilliseconds on SIGINT or SIGTERM.
+type HTTPServerConfig struct {
+	ReadTimeoutMilliseconds       int `yaml:"ReadTimeoutMilliseconds"`
+	ReadHeaderTimeoutMilliseconds int `yaml:"ReadHeaderTimeoutMilliseconds"`
+	WriteTimeoutMilliseconds      int `yaml:"WriteTimeoutMilliseconds"`
+	IdleTimeoutMilliseconds       int `yaml:"IdleTimeoutMilliseconds"`
+	ShutdownTimeoutMilliseconds   int `yaml:"ShutdownTimeoutMilliseconds"`
+}
+
+// HealthConfig sets the readiness checks. The currency converter probe asks for
+// a rate to the providers, so it is optional, and it is not critical since the
+// prices in the source currency are still served while it is down. Checks
+// time out after 2 seconds when CheckTimeoutMilliseconds is not set.
+type HealthConfig struct {
+	CheckTimeoutMilliseconds int  `yaml:"CheckTimeoutMilliseconds"`
+	CheckCurrencyConverter   bool `yaml:"CheckCurrencyConverter"`
+}
+
+// BeerImportConfig caps the size of the beer import body. Larger bodies are
+// answered with 413, and the cap is 10 MiB when MaxBodyBytes is not set.
+type BeerImportConfig struct {
+	MaxBodyBytes int `yaml:"MaxBodyBytes"`
 }
 
 type DBConfig struct {
 	UserName   string `yaml:"UserName"`
-	Password   string `yaml:"Password"`
+	Password   string `yaml:"Password" secret:"true"`
 	Host       string `yaml:"Host"`
 	DriverName string `yaml:"DriverName"`
 	DBName     string `yaml:"DBName"`
+	// SSLMode is the sslmode of the postgres driver, disable by default.
+	SSLMode string `yaml:"SSLMode"`
 }
 
 type CurrencyConverterRestClientConfig struct {
 	BaseURL                    string `yaml:"BaseURL"`
 	RequestTimeoutMilliseconds int    `yaml:"RequestTimeoutMilliseconds"`
-	XAPIKeyEnv                 string `yaml:"XAPIKeyEnv"`
+	XAPIKey                    string `yaml:"XAPIKey" secret:"true"`
+	CacheTTLMilliseconds       int    `yaml:"CacheTTLMilliseconds"`
+	// Retries apply to 429, 5xx and network errors. A zero
+	// CircuitBreakerFailureThreshold disables the circuit breaker.
+	RetryMaxAttempts                      int `yaml:"RetryMaxAttempts"`
+	RetryInitialBackoffMilliseconds       int `yaml:"RetryInitialBackoffMilliseconds"`
+	RetryMaxBackoffMilliseconds           int `yaml:"RetryMaxBackoffMilliseconds"`
+	CircuitBreakerFailureThreshold        int `yaml:"CircuitBreakerFailureThreshold"`
+	CircuitBreakerOpenTimeoutMilliseconds int `yaml:"CircuitBreakerOpenTimeoutMilliseconds"`
 }
 
-func NewConfig() *Config {
-	config := new(Config)
-	applyConfigFromString(config, []byte(environment.Test))
+type ECBCurrencyConverterClientConfig struct {
+	FeedURL                    string `yaml:"FeedURL"`
+	RequestTimeoutMilliseconds int    `yaml:"RequestTimeoutMilliseconds"`
+	CacheTTLMilliseconds       int    `yaml:"CacheTTLMilliseconds"`
+}
 
-	return config
+type StaticCurrencyConverterClientConfig struct {
+	RatesFilePath string `yaml:"RatesFilePath"`
 }
 
-func applyConfigFromString(config *Config, configBytes []byte) {
-	err := yaml.Unmarshal(configBytes, config)
+// AppEnvVariable selects the embedded config file when no --config path is
+// given. DefaultAppEnv is used when it is not set.
+const (
+	AppEnvVariable = "APP_ENV"
+	DefaultAppEnv  = "test"
+)
+
+// envOverridePrefix prefixes the environment variables that override the
+// config fields, named after their YAML path, such as BEERS_DBCONFIG_HOST.
+const envOverridePrefix = "BEERS"
 
+// NewConfig reads the YAML config file at path or, when path is empty, the one
+// of the APP_ENV environment. The fields set in environment variables override
+// the ones of the file, and the result is validated before its secrets are
+// resolved with secretResolver.
+func NewConfig(path string, secretResolver *SecretResolver) (*Config, error) {
+	configBytes, err := readConfigFile(path)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	config := new(Config)
+	if err := yaml.Unmarshal(configBytes, config); err != nil {
+		return nil, fmt.Errorf("invalid config file: %s", err)
+	}
+
+	if err := applyEnvOverrides(config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
+
+	if err := secretResolver.resolveConfig(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func readConfigFile(path string) ([]byte, error) {
+	if path != "" {
+		configBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error trying to read config file: %s", err)
+		}
+
+		return configBytes, nil
+	}
+
+	appEnv := os.Getenv(AppEnvVariable)
+	if appEnv == "" {
+		appEnv = DefaultAppEnv
+	}
+
+	return environment.Config(appEnv)
+}
+
+// applyEnvOverrides sets the fields of the config from their environment
+// variables. Lists are comma separated.
+func applyEnvOverrides(config *Config) error {
+	return forEachField(reflect.ValueOf(config).Elem(), nil, func(path []string, _ reflect.StructField, field reflect.Value) error {
+		name := envVariableName(path)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s should be a number", name, value)
+			}
+			field.SetInt(int64(number))
+		case reflect.Bool:
+			flag, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s should be true or false", name, value)
+			}
+			field.SetBool(flag)
+		case reflect.Slice:
+			items := make([]string, 0)
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		}
+
+		return nil
+	})
+}
+
+// forEachField calls fn with every field of the config that is not a struct,
+// along with the YAML keys leading to it.
+func forEachField(value reflect.Value, path []string, fn func(path []string, structField reflect.StructField, field reflect.Value) error) error {
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		fieldPath := append(append([]string{}, path...), structField.Tag.Get("yaml"))
+		field := value.Field(i)
+
+		var err error
+		if field.Kind() == reflect.Struct {
+			err = forEachField(field, fieldPath, fn)
+		} else {
+			err = fn(fieldPath, structField, field)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func envVariableName(path []string) string {
+	return envOverridePrefix + "_" + strings.ToUpper(strings.Join(path, "_"))
 }
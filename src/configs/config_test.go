@@ -1,14 +1,34 @@
 package configs_test
 
 import (
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/dleonsal/beers-api/src/configs"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
-func Test_NewConfig_ThenReturnConfig(t *testing.T) {
+func Test_NewConfig_WhenAppEnvIsNotSet_ThenReturnTestConfig(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "")
+	t.Setenv("DB_PASSWORD", "123456")
+	t.Setenv("CURRENCY_CONVERTER_X_API_KEY", "x-api-key")
 	expectedConfig := configs.Config{
 		Port: "8080",
+		HTTPServerConfig: configs.HTTPServerConfig{
+			ReadTimeoutMilliseconds:       10000,
+			ReadHeaderTimeoutMilliseconds: 5000,
+			WriteTimeoutMilliseconds:      60000,
+			IdleTimeoutMilliseconds:       120000,
+			ShutdownTimeoutMilliseconds:   10000,
+		},
+		HealthConfig: configs.HealthConfig{
+			CheckTimeoutMilliseconds: 2000,
+			CheckCurrencyConverter:   true,
+		},
+		BeerImportConfig: configs.BeerImportConfig{
+			MaxBodyBytes: 10485760,
+		},
 		DBConfig: configs.DBConfig{
 			UserName:   "root",
 			Password:   "123456",
@@ -16,15 +36,144 @@ func Test_NewConfig_ThenReturnConfig(t *testing.T) {
 			DriverName: "mysql",
 			DBName:     "BEERSDB",
 		},
+		CurrencyConverterProviders: []string{"rest", "ecb"},
 		CurrencyConverterRestClientConfig: configs.CurrencyConverterRestClientConfig{
-			BaseURL:                    "https://currency-exchange.p.rapidapi.com",
-			RequestTimeoutMilliseconds: 5000,
-			XAPIKeyEnv:                 "CURRENCY_CONVERTER_X_API_KEY",
+			BaseURL:                               "https://currency-exchange.p.rapidapi.com",
+			RequestTimeoutMilliseconds:            5000,
+			XAPIKey:                               "x-api-key",
+			CacheTTLMilliseconds:                  60000,
+			RetryMaxAttempts:                      3,
+			RetryInitialBackoffMilliseconds:       100,
+			RetryMaxBackoffMilliseconds:           2000,
+			CircuitBreakerFailureThreshold:        5,
+			CircuitBreakerOpenTimeoutMilliseconds: 30000,
+		},
+		ECBCurrencyConverterClientConfig: configs.ECBCurrencyConverterClientConfig{
+			FeedURL:                    "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml",
+			RequestTimeoutMilliseconds: 3000,
+			CacheTTLMilliseconds:       3600000,
+		},
+		StaticCurrencyConverterClientConfig: configs.StaticCurrencyConverterClientConfig{
+			RatesFilePath: "src/configs/rates/static_rates.yaml",
 		},
 		HTTPClientTimeoutMilliseconds: 5100,
 	}
 
-	config := configs.NewConfig()
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
 
+	assert.Nil(t, err)
 	assert.Equal(t, expectedConfig, *config)
 }
+
+func Test_NewConfig_WhenAppEnvIsLocal_ThenReturnSQLiteConfig(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "local")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, err)
+	assert.Equal(t, configs.SQLiteDriverName, config.DBConfig.DriverName)
+	assert.Equal(t, []string{configs.StaticCurrencyConverterProvider}, config.CurrencyConverterProviders)
+}
+
+func Test_NewConfig_WhenAppEnvIsUnknown_ThenReturnError(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "staging")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, config)
+	assert.EqualError(t, err, "unknown environment: staging")
+}
+
+func Test_NewConfig_WhenAppEnvIsProductionWithoutDBOverrides_ThenReturnValidationError(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "production")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, config)
+	assert.EqualError(t, err, "invalid config: DBConfig.Host is required (BEERS_DBCONFIG_HOST); "+
+		"DBConfig.UserName is required (BEERS_DBCONFIG_USERNAME)")
+}
+
+func Test_NewConfig_WhenEnvOverridesFields_ThenReturnOverriddenConfig(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "production")
+	t.Setenv("BEERS_PORT", "9090")
+	t.Setenv("BEERS_DBCONFIG_HOST", "db.internal:3306")
+	t.Setenv("BEERS_DBCONFIG_USERNAME", "beers")
+	t.Setenv("BEERS_DBCONFIG_PASSWORD", "env:BEERS_TEST_DB_PASSWORD")
+	t.Setenv("BEERS_TEST_DB_PASSWORD", "s3cret")
+	t.Setenv("BEERS_CURRENCYCONVERTERRESTCLIENTCONFIG_XAPIKEY", "")
+	t.Setenv("BEERS_CURRENCYCONVERTERPROVIDERS", "ecb, static")
+	t.Setenv("BEERS_STATICCURRENCYCONVERTERCLIENTCONFIG_RATESFILEPATH", "rates.yaml")
+	t.Setenv("BEERS_HTTPCLIENTTIMEOUTMILLISECONDS", "2000")
+	t.Setenv("BEERS_HEALTHCONFIG_CHECKCURRENCYCONVERTER", "false")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "9090", config.Port)
+	assert.Equal(t, "db.internal:3306", config.DBConfig.Host)
+	assert.Equal(t, "beers", config.DBConfig.UserName)
+	assert.Equal(t, "BEERSDB", config.DBConfig.DBName)
+	assert.Equal(t, "s3cret", config.DBConfig.Password)
+	assert.Equal(t, []string{"ecb", "static"}, config.CurrencyConverterProviders)
+	assert.Equal(t, "rates.yaml", config.StaticCurrencyConverterClientConfig.RatesFilePath)
+	assert.Equal(t, 2000, config.HTTPClientTimeoutMilliseconds)
+	assert.False(t, config.HealthConfig.CheckCurrencyConverter)
+}
+
+func Test_NewConfig_WhenNumberOverrideIsInvalid_ThenReturnError(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "")
+	t.Setenv("BEERS_HTTPCLIENTTIMEOUTMILLISECONDS", "5s")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, config)
+	assert.EqualError(t, err, "invalid BEERS_HTTPCLIENTTIMEOUTMILLISECONDS: 5s should be a number")
+}
+
+func Test_NewConfig_WhenBoolOverrideIsInvalid_ThenReturnError(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "")
+	t.Setenv("BEERS_HEALTHCONFIG_CHECKCURRENCYCONVERTER", "sometimes")
+
+	config, err := configs.NewConfig("", configs.NewSecretResolver())
+
+	assert.Nil(t, config)
+	assert.EqualError(t, err, "invalid BEERS_HEALTHCONFIG_CHECKCURRENCYCONVERTER: sometimes should be true or false")
+}
+
+func Test_NewConfig_WhenPathIsSet_ThenReadConfigFile(t *testing.T) {
+	t.Setenv(configs.AppEnvVariable, "production")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("Port: 8081\nDBConfig:\n  DriverName: memory\n"+
+		"CurrencyConverterProviders: [static]\nStaticCurrencyConverterClientConfig:\n  RatesFilePath: rates.yaml\n"), 0600)
+
+	config, err := configs.NewConfig(path, configs.NewSecretResolver())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "8081", config.Port)
+	assert.Equal(t, configs.MemoryDriverName, config.DBConfig.DriverName)
+}
+
+func Test_NewConfig_WhenPathDoesNotExist_ThenReturnError(t *testing.T) {
+	config, err := configs.NewConfig(filepath.Join(t.TempDir(), "config.yaml"), configs.NewSecretResolver())
+
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "error trying to read config file")
+}
+
+func Test_Validate_WhenConfigHasSeveralProblems_ThenReportThemAll(t *testing.T) {
+	config := configs.Config{
+		Port:                          "http",
+		DBConfig:                      configs.DBConfig{DriverName: "oracle"},
+		CurrencyConverterProviders:    []string{"rest", "fixer"},
+		HTTPClientTimeoutMilliseconds: -1,
+	}
+
+	err := config.Validate()
+
+	assert.EqualError(t, err, "invalid config: Port should be a number between 1 and 65535; "+
+		"DBConfig.DriverName should be mysql, postgres, sqlite or memory; "+
+		"CurrencyConverterRestClientConfig.BaseURL is required (BEERS_CURRENCYCONVERTERRESTCLIENTCONFIG_BASEURL); "+
+		"unknown currency converter provider: fixer; "+
+		"HTTPClientTimeoutMilliseconds should not be negative")
+}
@@ -0,0 +1,20 @@
+// Package environment embeds the config file of each APP_ENV environment.
+package environment
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed *.yaml
+var files embed.FS
+
+// Config returns the YAML config file of the environment.
+func Config(name string) ([]byte, error) {
+	content, err := files.ReadFile(name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unknown environment: %s", name)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,118 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Schemes of the secret references resolved by default, such as
+// env:DB_PASSWORD or file:/run/secrets/db_password.
+const (
+	EnvSecretScheme  = "env"
+	FileSecretScheme = "file"
+)
+
+const redactedSecret = "[REDACTED]"
+
+// SecretProvider resolves the secret references of a scheme. For
+// vault:beers/db, the provider registered for vault receives beers/db.
+type SecretProvider interface {
+	Resolve(reference string) (string, error)
+}
+
+// SecretResolver resolves the config fields tagged as secret with the provider
+// of their scheme.
+type SecretResolver struct {
+	providers map[string]SecretProvider
+}
+
+// NewSecretResolver returns a resolver with the env and file providers, the
+// latter for Docker and Kubernetes secrets.
+func NewSecretResolver() *SecretResolver {
+	return &SecretResolver{
+		providers: map[string]SecretProvider{
+			EnvSecretScheme:  envSecretProvider{},
+			FileSecretScheme: fileSecretProvider{},
+		},
+	}
+}
+
+// Register adds the provider of a scheme, replacing the previous one.
+func (r *SecretResolver) Register(scheme string, provider SecretProvider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns the secret referenced by a scheme:reference value. Values
+// without a registered scheme are secrets themselves and returned as they are.
+func (r *SecretResolver) Resolve(value string) (string, error) {
+	scheme, reference, found := strings.Cut(value, ":")
+	if !found {
+		return value, nil
+	}
+
+	provider, ok := r.providers[scheme]
+	if !ok {
+		return value, nil
+	}
+
+	return provider.Resolve(reference)
+}
+
+func (r *SecretResolver) resolveConfig(config *Config) error {
+	return forEachField(reflect.ValueOf(config).Elem(), nil, func(path []string, structField reflect.StructField, field reflect.Value) error {
+		if !isSecret(structField) {
+			return nil
+		}
+
+		secret, err := r.Resolve(field.String())
+		if err != nil {
+			return fmt.Errorf("error trying to resolve %s: %s", strings.Join(path, "."), err)
+		}
+		field.SetString(secret)
+
+		return nil
+	})
+}
+
+// String formats the config with its secrets redacted, so it can be logged.
+func (c Config) String() string {
+	forEachField(reflect.ValueOf(&c).Elem(), nil, func(_ []string, structField reflect.StructField, field reflect.Value) error {
+		if isSecret(structField) && field.String() != "" {
+			field.SetString(redactedSecret)
+		}
+
+		return nil
+	})
+
+	type plainConfig Config
+	return fmt.Sprintf("%+v", plainConfig(c))
+}
+
+func isSecret(structField reflect.StructField) bool {
+	return structField.Tag.Get("secret") == "true"
+}
+
+type envSecretProvider struct{}
+
+func (envSecretProvider) Resolve(name string) (string, error) {
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return secret, nil
+}
+
+type fileSecretProvider struct{}
+
+// Resolve drops the trailing line break that secret files usually end with.
+func (fileSecretProvider) Resolve(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error trying to read secret file: %s", err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,102 @@
+package configs_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSecretProvider map[string]string
+
+func (p fakeSecretProvider) Resolve(reference string) (string, error) {
+	secret, ok := p[reference]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", reference)
+	}
+
+	return secret, nil
+}
+
+func Test_Resolve_WhenValueIsEnvReference_ThenReturnEnvValue(t *testing.T) {
+	t.Setenv("BEERS_TEST_SECRET", "s3cret")
+
+	secret, err := configs.NewSecretResolver().Resolve("env:BEERS_TEST_SECRET")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cret", secret)
+}
+
+func Test_Resolve_WhenEnvIsNotSet_ThenReturnError(t *testing.T) {
+	secret, err := configs.NewSecretResolver().Resolve("env:BEERS_TEST_MISSING_SECRET")
+
+	assert.Equal(t, "", secret)
+	assert.EqualError(t, err, "environment variable BEERS_TEST_MISSING_SECRET is not set")
+}
+
+func Test_Resolve_WhenValueIsFileReference_ThenReturnFileContentWithoutLineBreak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db_password")
+	os.WriteFile(path, []byte("s3cret\n"), 0600)
+
+	secret, err := configs.NewSecretResolver().Resolve("file:" + path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cret", secret)
+}
+
+func Test_Resolve_WhenFileDoesNotExist_ThenReturnError(t *testing.T) {
+	secret, err := configs.NewSecretResolver().Resolve("file:" + filepath.Join(t.TempDir(), "db_password"))
+
+	assert.Equal(t, "", secret)
+	assert.Contains(t, err.Error(), "error trying to read secret file")
+}
+
+func Test_Resolve_WhenSchemeIsNotRegistered_ThenReturnValue(t *testing.T) {
+	secret, err := configs.NewSecretResolver().Resolve("p4ss:word")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "p4ss:word", secret)
+}
+
+func Test_Resolve_WhenProviderIsRegistered_ThenUseProvider(t *testing.T) {
+	resolver := configs.NewSecretResolver()
+	resolver.Register("vault", fakeSecretProvider{"beers/db": "s3cret"})
+
+	secret, err := resolver.Resolve("vault:beers/db")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "s3cret", secret)
+}
+
+func Test_NewConfig_WhenSecretCannotBeResolved_ThenReturnFieldError(t *testing.T) {
+	resolver := configs.NewSecretResolver()
+	resolver.Register("vault", fakeSecretProvider{})
+	t.Setenv(configs.AppEnvVariable, "local")
+	t.Setenv("BEERS_DBCONFIG_PASSWORD", "vault:beers/db")
+
+	config, err := configs.NewConfig("", resolver)
+
+	assert.Nil(t, config)
+	assert.EqualError(t, err, "error trying to resolve DBConfig.Password: secret beers/db not found")
+}
+
+func Test_String_WhenConfigHasSecrets_ThenRedactThem(t *testing.T) {
+	config := configs.Config{
+		Port:     "8080",
+		DBConfig: configs.DBConfig{UserName: "root", Password: "s3cret"},
+		CurrencyConverterRestClientConfig: configs.CurrencyConverterRestClientConfig{
+			XAPIKey: "x-api-key",
+		},
+	}
+
+	text := fmt.Sprintf("%s %v", config, &config)
+
+	assert.NotContains(t, text, "s3cret")
+	assert.NotContains(t, text, "x-api-key")
+	assert.Contains(t, text, "Password:[REDACTED]")
+	assert.Contains(t, text, "UserName:root")
+	assert.Equal(t, "s3cret", config.DBConfig.Password)
+}
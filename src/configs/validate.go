@@ -0,0 +1,73 @@
+package configs
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Validate checks the config before the application is wired, reporting every
+// problem found instead of failing on the first one.
+func (c *Config) Validate() error {
+	var problems []string
+	required := func(value string, path ...string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, fmt.Sprintf("%s is required (%s)", strings.Join(path, "."), envVariableName(path)))
+		}
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, "Port should be a number between 1 and 65535")
+	}
+
+	switch c.DBConfig.DriverName {
+	case MySQLDriverName, PostgresDriverName:
+		required(c.DBConfig.Host, "DBConfig", "Host")
+		required(c.DBConfig.UserName, "DBConfig", "UserName")
+		required(c.DBConfig.DBName, "DBConfig", "DBName")
+	case SQLiteDriverName:
+		required(c.DBConfig.DBName, "DBConfig", "DBName")
+	case MemoryDriverName:
+	default:
+		problems = append(problems, fmt.Sprintf("DBConfig.DriverName should be %s, %s, %s or %s",
+			MySQLDriverName, PostgresDriverName, SQLiteDriverName, MemoryDriverName))
+	}
+
+	for _, provider := range c.CurrencyConverterProviderNames() {
+		switch provider {
+		case RestCurrencyConverterProvider:
+			required(c.CurrencyConverterRestClientConfig.BaseURL, "CurrencyConverterRestClientConfig", "BaseURL")
+		case ECBCurrencyConverterProvider:
+			required(c.ECBCurrencyConverterClientConfig.FeedURL, "ECBCurrencyConverterClientConfig", "FeedURL")
+		case StaticCurrencyConverterProvider:
+			required(c.StaticCurrencyConverterClientConfig.RatesFilePath, "StaticCurrencyConverterClientConfig", "RatesFilePath")
+		default:
+			problems = append(problems, fmt.Sprintf("unknown currency converter provider: %s", provider))
+		}
+	}
+
+	forEachField(reflect.ValueOf(c).Elem(), nil, func(path []string, _ reflect.StructField, field reflect.Value) error {
+		if field.Kind() == reflect.Int && field.Int() < 0 {
+			problems = append(problems, fmt.Sprintf("%s should not be negative", strings.Join(path, ".")))
+		}
+
+		return nil
+	})
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// CurrencyConverterProviderNames returns the configured providers, or the rest
+// one when none is set.
+func (c *Config) CurrencyConverterProviderNames() []string {
+	if len(c.CurrencyConverterProviders) == 0 {
+		return []string{RestCurrencyConverterProvider}
+	}
+
+	return c.CurrencyConverterProviders
+}
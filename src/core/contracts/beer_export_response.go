@@ -0,0 +1,40 @@
+package contracts
+
+import (
+	"encoding/json"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/shopspring/decimal"
+)
+
+type BeerExportResponse struct {
+	Id             int64           `json:"Id"`
+	Name           string          `json:"Name"`
+	Brewery        string          `json:"Brewery"`
+	Country        string          `json:"Country"`
+	Price          decimal.Decimal `json:"Price"`
+	Currency       string          `json:"Currency"`
+	ConvertedPrice *money.Money    `json:"Converted Price,omitempty"`
+}
+
+type beerExportResponseJSON struct {
+	Id             int64           `json:"Id"`
+	Name           string          `json:"Name"`
+	Brewery        string          `json:"Brewery"`
+	Country        string          `json:"Country"`
+	Price          json.RawMessage `json:"Price"`
+	Currency       string          `json:"Currency"`
+	ConvertedPrice *money.Money    `json:"Converted Price,omitempty"`
+}
+
+// MarshalJSON writes the price as a JSON number.
+func (r BeerExportResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(beerExportResponseJSON{
+		Id:             r.Id,
+		Name:           r.Name,
+		Brewery:        r.Brewery,
+		Country:        r.Country,
+		Price:          money.JSONNumber(r.Price),
+		Currency:       r.Currency,
+		ConvertedPrice: r.ConvertedPrice,
+	})
+}
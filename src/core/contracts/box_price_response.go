@@ -1,5 +1,88 @@
 package contracts
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/shopspring/decimal"
+)
+
 type BoxPriceResponse struct {
-	TotalPrice float64 `json:"Total Price"`
-}
\ No newline at end of file
+	BeerID         int64                  `json:"Beer Id"`
+	SourceCurrency string                 `json:"Source Currency"`
+	TargetCurrency string                 `json:"Target Currency"`
+	ExchangeRate   decimal.Decimal        `json:"Exchange Rate"`
+	UnitPrice      money.Money            `json:"Unit Price"`
+	Quantity       uint64                 `json:"Quantity"`
+	Subtotal       money.Money            `json:"Subtotal"`
+	Discounts      []DiscountLineResponse `json:"Discounts"`
+	Pack           *PackResponse          `json:"Pack,omitempty"`
+	PackagingCost  *money.Money           `json:"Packaging Cost,omitempty"`
+	Destination    string                 `json:"Destination,omitempty"`
+	NetPrice       money.Money            `json:"Net Price"`
+	Taxes          []TaxLineResponse      `json:"Taxes"`
+	TotalPrice     money.Money            `json:"Total Price"`
+	RateProvider   string                 `json:"Rate Provider,omitempty"`
+	RateTimestamp  *time.Time             `json:"Rate Timestamp,omitempty"`
+}
+
+type boxPriceResponseJSON struct {
+	BeerID         int64                  `json:"Beer Id"`
+	SourceCurrency string                 `json:"Source Currency"`
+	TargetCurrency string                 `json:"Target Currency"`
+	ExchangeRate   json.RawMessage        `json:"Exchange Rate"`
+	UnitPrice      money.Money            `json:"Unit Price"`
+	Quantity       uint64                 `json:"Quantity"`
+	Subtotal       money.Money            `json:"Subtotal"`
+	Discounts      []DiscountLineResponse `json:"Discounts"`
+	Pack           *PackResponse          `json:"Pack,omitempty"`
+	PackagingCost  *money.Money           `json:"Packaging Cost,omitempty"`
+	Destination    string                 `json:"Destination,omitempty"`
+	NetPrice       money.Money            `json:"Net Price"`
+	Taxes          []TaxLineResponse      `json:"Taxes"`
+	TotalPrice     money.Money            `json:"Total Price"`
+	RateProvider   string                 `json:"Rate Provider,omitempty"`
+	RateTimestamp  *time.Time             `json:"Rate Timestamp,omitempty"`
+}
+
+// MarshalJSON writes the exchange rate as a JSON number.
+func (r BoxPriceResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(boxPriceResponseJSON{
+		BeerID:         r.BeerID,
+		SourceCurrency: r.SourceCurrency,
+		TargetCurrency: r.TargetCurrency,
+		ExchangeRate:   money.JSONNumber(r.ExchangeRate),
+		UnitPrice:      r.UnitPrice,
+		Quantity:       r.Quantity,
+		Subtotal:       r.Subtotal,
+		Discounts:      r.Discounts,
+		Pack:           r.Pack,
+		PackagingCost:  r.PackagingCost,
+		Destination:    r.Destination,
+		NetPrice:       r.NetPrice,
+		Taxes:          r.Taxes,
+		TotalPrice:     r.TotalPrice,
+		RateProvider:   r.RateProvider,
+		RateTimestamp:  r.RateTimestamp,
+	})
+}
+
+type DiscountLineResponse struct {
+	RuleID      int64       `json:"Rule Id,omitempty"`
+	CouponCode  string      `json:"Coupon Code,omitempty"`
+	Description string      `json:"Description"`
+	Amount      money.Money `json:"Amount"`
+}
+
+type PackResponse struct {
+	Id   int64  `json:"Id"`
+	Name string `json:"Name"`
+	Size uint64 `json:"Size"`
+}
+
+type TaxLineResponse struct {
+	RuleID      int64       `json:"Rule Id"`
+	Description string      `json:"Description"`
+	Amount      money.Money `json:"Amount"`
+}
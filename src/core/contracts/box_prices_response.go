@@ -0,0 +1,13 @@
+package contracts
+
+import "github.com/dleonsal/beers-api/src/errors"
+
+type BoxPricesResponse struct {
+	BoxPrices []BoxPriceResultResponse `json:"Box Prices"`
+}
+
+type BoxPriceResultResponse struct {
+	Currency string            `json:"Currency"`
+	Quote    *BoxPriceResponse `json:"Quote,omitempty"`
+	Error    *errors.RestError `json:"Error,omitempty"`
+}
@@ -0,0 +1,22 @@
+package contracts
+
+// Statuses of the health responses. A degraded application is still ready,
+// since only non-critical dependencies are down.
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+type HealthResponse struct {
+	Status       string                     `json:"Status"`
+	Dependencies []DependencyHealthResponse `json:"Dependencies,omitempty"`
+}
+
+type DependencyHealthResponse struct {
+	Name                string  `json:"Name"`
+	Status              string  `json:"Status"`
+	Critical            bool    `json:"Critical"`
+	LatencyMilliseconds float64 `json:"Latency Milliseconds"`
+	Error               string  `json:"Error,omitempty"`
+}
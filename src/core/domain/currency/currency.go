@@ -0,0 +1,56 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+// Currency is an ISO 4217 currency.
+type Currency struct {
+	Code       string `json:"Code"`
+	Name       string `json:"Name"`
+	MinorUnits int32  `json:"MinorUnits"`
+}
+
+var currenciesByCode = indexByCode(registry)
+
+func indexByCode(currencies []Currency) map[string]Currency {
+	index := make(map[string]Currency, len(currencies))
+	for _, currency := range currencies {
+		index[currency.Code] = currency
+	}
+
+	return index
+}
+
+// All returns every currency of the registry sorted by code.
+func All() []Currency {
+	currencies := make([]Currency, len(registry))
+	copy(currencies, registry)
+
+	return currencies
+}
+
+// Lookup returns the currency with the given code. The code must already be normalized.
+func Lookup(code string) (Currency, bool) {
+	currency, ok := currenciesByCode[code]
+
+	return currency, ok
+}
+
+// Normalize trims and upper-cases the code and checks that it belongs to the registry.
+func Normalize(code string) (string, *errors.RestError) {
+	normalizedCode := strings.ToUpper(strings.TrimSpace(code))
+	if len(normalizedCode) == 0 {
+		return "", errors.NewBadRequestError("currency must not be empty")
+	}
+
+	if _, ok := Lookup(normalizedCode); !ok {
+		return "", errors.NewBadRequestError(
+			fmt.Sprintf("invalid currency: %s, it must be an ISO 4217 code", code))
+	}
+
+	return normalizedCode, nil
+}
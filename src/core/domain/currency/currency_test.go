@@ -0,0 +1,52 @@
+package currency_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Normalize_WhenCodeIsEmpty_ThenReturnBadRequestError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("currency must not be empty")
+
+	code, err := currency.Normalize("  ")
+
+	assert.Empty(t, code)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Normalize_WhenCodeIsNotISO4217_ThenReturnBadRequestError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid currency: Dollars, it must be an ISO 4217 code")
+
+	code, err := currency.Normalize("Dollars")
+
+	assert.Empty(t, code)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Normalize_WhenCodeIsLowerCase_ThenReturnUpperCaseCode(t *testing.T) {
+	code, err := currency.Normalize(" usd ")
+
+	assert.Equal(t, "USD", code)
+	assert.Nil(t, err)
+}
+
+func Test_Lookup_WhenCodeExists_ThenReturnCurrency(t *testing.T) {
+	expectedCurrency := currency.Currency{Code: "JPY", Name: "Yen", MinorUnits: 0}
+
+	jpy, ok := currency.Lookup("JPY")
+
+	assert.True(t, ok)
+	assert.Equal(t, expectedCurrency, jpy)
+}
+
+func Test_All_WhenCalled_ThenReturnCurrenciesSortedByCode(t *testing.T) {
+	currencies := currency.All()
+
+	assert.True(t, sort.SliceIsSorted(currencies, func(i, j int) bool {
+		return currencies[i].Code < currencies[j].Code
+	}))
+}
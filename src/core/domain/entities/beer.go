@@ -1,23 +1,54 @@
 package entities
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
 	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
 )
 
 type Beer struct {
-	Id       int64   `json:"Id"`
-	Name     string  `json:"Name"`
-	Brewery  string  `json:"Brewery"`
-	Country  string  `json:"Country"`
-	Price    float64 `json:"Price"`
-	Currency string  `json:"Currency"`
+	Id       int64           `json:"Id"`
+	Name     string          `json:"Name"`
+	Brewery  string          `json:"Brewery"`
+	Country  string          `json:"Country"`
+	Price    decimal.Decimal `json:"Price"`
+	Currency string          `json:"Currency"`
 }
 
+type beerJSON struct {
+	Id       int64           `json:"Id"`
+	Name     string          `json:"Name"`
+	Brewery  string          `json:"Brewery"`
+	Country  string          `json:"Country"`
+	Price    json.RawMessage `json:"Price"`
+	Currency string          `json:"Currency"`
+}
+
+// MarshalJSON writes the price as a JSON number.
+func (b Beer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(beerJSON{
+		Id:       b.Id,
+		Name:     b.Name,
+		Brewery:  b.Brewery,
+		Country:  b.Country,
+		Price:    money.JSONNumber(b.Price),
+		Currency: b.Currency,
+	})
+}
+
+// UnitPrice returns the price of a single beer in its own currency.
+func (b *Beer) UnitPrice() money.Money {
+	return money.New(b.Price, b.Currency)
+}
+
+// Validate checks the beer fields and normalizes its currency code.
 func (b *Beer) Validate() *errors.RestError {
-	if b.Id == 0 {
+	if b.Id < 0 {
 		return errors.NewBadRequestError(
 			fmt.Sprintf("invalid Id: %d", b.Id))
 	}
@@ -37,15 +68,17 @@ func (b *Beer) Validate() *errors.RestError {
 			fmt.Sprintf("invalid Country: %s", b.Country))
 	}
 
-	if b.Price == 0 {
+	if b.Price.Sign() <= 0 {
 		return errors.NewBadRequestError(
-			fmt.Sprintf("invalid Price: %f", b.Price))
+			fmt.Sprintf("invalid Price: %s", b.Price))
 	}
 
-	if len(strings.TrimSpace(b.Currency)) == 0 {
+	currencyCode, err := currency.Normalize(b.Currency)
+	if err != nil {
 		return errors.NewBadRequestError(
 			fmt.Sprintf("invalid Currency: %s", b.Currency))
 	}
+	b.Currency = currencyCode
 
 	return nil
 }
@@ -0,0 +1,10 @@
+package entities
+
+import "github.com/dleonsal/beers-api/src/core/domain/money"
+
+// BeerExport is a row of a catalog export. ConvertedPrice is only set when the
+// export was requested in a target currency.
+type BeerExport struct {
+	Beer           Beer
+	ConvertedPrice *money.Money
+}
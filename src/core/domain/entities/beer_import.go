@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+// Beer import modes. An all or nothing import saves no beer when any row
+// fails, while a best effort import saves every valid row.
+const (
+	AllOrNothingImport = "all_or_nothing"
+	BestEffortImport   = "best_effort"
+)
+
+// BeerImportRow is a row of an import file. Err is set when the row could not
+// be parsed into a beer. Row numbers start at 1 and do not count the header.
+type BeerImportRow struct {
+	Row  int
+	Beer Beer
+	Err  *errors.RestError
+}
+
+// BeerSaveResult is the outcome of saving a single beer of a batch, either its
+// id or the reason it was not saved.
+type BeerSaveResult struct {
+	Id  int64
+	Err *errors.RestError
+}
+
+type BeerImportResult struct {
+	Row   int               `json:"Row"`
+	Id    int64             `json:"Id,omitempty"`
+	Error *errors.RestError `json:"Error,omitempty"`
+}
+
+// BeerImportReport is the outcome of an import with a result for every row.
+type BeerImportReport struct {
+	Mode     string             `json:"Mode"`
+	Total    int                `json:"Total"`
+	Imported int                `json:"Imported"`
+	Failed   int                `json:"Failed"`
+	Rows     []BeerImportResult `json:"Rows"`
+}
+
+// ValidateImportMode returns the mode, all or nothing when it is empty.
+func ValidateImportMode(mode string) (string, *errors.RestError) {
+	switch mode {
+	case "":
+		return AllOrNothingImport, nil
+	case AllOrNothingImport, BestEffortImport:
+		return mode, nil
+	default:
+		return "", errors.NewBadRequestError(
+			fmt.Sprintf("invalid mode: %s, it must be %s or %s", mode, AllOrNothingImport, BestEffortImport))
+	}
+}
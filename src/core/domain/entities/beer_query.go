@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultBeerQueryLimit = 20
+	MaxBeerQueryLimit     = 100
+)
+
+var beerSortFields = map[string]bool{
+	"id":       true,
+	"name":     true,
+	"brewery":  true,
+	"country":  true,
+	"price":    true,
+	"currency": true,
+}
+
+// BeerQuery holds the pagination, filtering and sorting options used to list beers.
+type BeerQuery struct {
+	Limit    int
+	Offset   int
+	Country  string
+	Brewery  string
+	Currency string
+	MinPrice *decimal.Decimal
+	MaxPrice *decimal.Decimal
+	Sort     []SortField
+}
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+func NewBeerQuery() BeerQuery {
+	return BeerQuery{
+		Limit: DefaultBeerQueryLimit,
+	}
+}
+
+// ParseSort parses a comma separated list of fields such as "price,-name",
+// where a leading "-" means descending order.
+func ParseSort(sort string) ([]SortField, *errors.RestError) {
+	if len(strings.TrimSpace(sort)) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]SortField, 0)
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		descending := strings.HasPrefix(field, "-")
+		field = strings.ToLower(strings.TrimPrefix(field, "-"))
+
+		if !beerSortFields[field] {
+			return nil, errors.NewBadRequestError(
+				fmt.Sprintf("invalid sort field: %s", field))
+		}
+
+		fields = append(fields, SortField{Field: field, Descending: descending})
+	}
+
+	return fields, nil
+}
+
+// Validate checks the query options and normalizes the currency filter.
+func (q *BeerQuery) Validate() *errors.RestError {
+	if q.Limit <= 0 || q.Limit > MaxBeerQueryLimit {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid limit: %d, it must be between 1 and %d", q.Limit, MaxBeerQueryLimit))
+	}
+
+	if q.Offset < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid offset: %d", q.Offset))
+	}
+
+	return q.ValidateFilters()
+}
+
+// ValidateFilters checks the filtering and sorting options, ignoring the
+// pagination, and normalizes the currency filter.
+func (q *BeerQuery) ValidateFilters() *errors.RestError {
+	if q.Currency != "" {
+		currencyCode, err := currency.Normalize(q.Currency)
+		if err != nil {
+			return err
+		}
+		q.Currency = currencyCode
+	}
+
+	if q.MinPrice != nil && q.MaxPrice != nil && q.MinPrice.GreaterThan(*q.MaxPrice) {
+		return errors.NewBadRequestError("min_price must not be greater than max_price")
+	}
+
+	for _, sortField := range q.Sort {
+		if !beerSortFields[sortField.Field] {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid sort field: %s", sortField.Field))
+		}
+	}
+
+	return nil
+}
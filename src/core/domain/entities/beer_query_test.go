@@ -0,0 +1,59 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseSort_WhenFieldIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid sort field: color")
+
+	sort, err := entities.ParseSort("price,-color")
+
+	assert.Nil(t, sort)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ParseSort_WhenFieldsAreValid_ThenReturnSortFields(t *testing.T) {
+	expectedSort := []entities.SortField{{Field: "price"}, {Field: "name", Descending: true}}
+
+	sort, err := entities.ParseSort("price, -Name")
+
+	assert.Equal(t, expectedSort, sort)
+	assert.Nil(t, err)
+}
+
+func Test_BeerQueryValidate_WhenOffsetIsNegative_ThenReturnBadRequestError(t *testing.T) {
+	query := entities.NewBeerQuery()
+	query.Offset = -1
+	expectedError := errors.NewBadRequestError("invalid offset: -1")
+
+	err := query.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_BeerQueryValidate_WhenPriceRangeIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	minPrice := decimal.NewFromInt(10)
+	maxPrice := decimal.NewFromInt(5)
+	query := entities.NewBeerQuery()
+	query.MinPrice = &minPrice
+	query.MaxPrice = &maxPrice
+	expectedError := errors.NewBadRequestError("min_price must not be greater than max_price")
+
+	err := query.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_BeerQueryValidate_WhenQueryIsValid_ThenReturnNil(t *testing.T) {
+	query := entities.NewBeerQuery()
+
+	err := query.Validate()
+
+	assert.Nil(t, err)
+}
@@ -1,17 +1,19 @@
 package entities_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/dleonsal/beers-api/src/core/domain/entities"
 	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_Validate_WhenIdIsInvalid_ThenReturnBadRequestError(t *testing.T) {
 	beer := entities.Beer{
-		Id: 0,
+		Id: -1,
 	}
 	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Id: %d", beer.Id))
 
@@ -65,9 +67,9 @@ func Test_Validate_WhenPriceIsInvalid_ThenReturnBadRequestError(t *testing.T) {
 		Name:    "Pilsen",
 		Brewery: "Bavaria",
 		Country: "Colombia",
-		Price:   0.0,
+		Price:   decimal.Zero,
 	}
-	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Price: %f", beer.Price))
+	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Price: %s", beer.Price))
 
 	err := beer.Validate()
 
@@ -80,7 +82,7 @@ func Test_Validate_WhenCurrencyIsInvalid_ThenReturnBadRequestError(t *testing.T)
 		Name:     "Pilsen",
 		Brewery:  "Bavaria",
 		Country:  "Colombia",
-		Price:    2500,
+		Price:    decimal.NewFromInt(2500),
 		Currency: "",
 	}
 	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Currency: %s", beer.Currency))
@@ -90,13 +92,59 @@ func Test_Validate_WhenCurrencyIsInvalid_ThenReturnBadRequestError(t *testing.T)
 	assert.Equal(t, expectedError, err)
 }
 
+func Test_Validate_WhenCurrencyIsNotISO4217_ThenReturnBadRequestError(t *testing.T) {
+	beer := entities.Beer{
+		Id:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    decimal.NewFromInt(2500),
+		Currency: "Dollars",
+	}
+	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Currency: %s", beer.Currency))
+
+	err := beer.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Validate_WhenCurrencyIsLowerCase_ThenNormalizeCurrency(t *testing.T) {
+	beer := entities.Beer{
+		Id:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    decimal.NewFromInt(2500),
+		Currency: " usd",
+	}
+
+	err := beer.Validate()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", beer.Currency)
+}
+
+func Test_Validate_WhenIdIsEmpty_ThenReturnNil(t *testing.T) {
+	beer := entities.Beer{
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    decimal.NewFromInt(2500),
+		Currency: "COP",
+	}
+
+	err := beer.Validate()
+
+	assert.Nil(t, err)
+}
+
 func Test_Validate_WhenBeerIsValid_ThenReturnNil(t *testing.T) {
 	beer := entities.Beer{
 		Id:       1,
 		Name:     "Pilsen",
 		Brewery:  "Bavaria",
 		Country:  "Colombia",
-		Price:    2500,
+		Price:    decimal.NewFromInt(2500),
 		Currency: "COP",
 	}
 
@@ -104,3 +152,12 @@ func Test_Validate_WhenBeerIsValid_ThenReturnNil(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func Test_MarshalJSON_WhenBeerIsMarshaled_ThenPriceIsNumber(t *testing.T) {
+	beer := entities.Beer{Id: 1, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: decimal.RequireFromString("2500.50"), Currency: "COP"}
+
+	bytes, err := json.Marshal(beer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Id":1,"Name":"Pilsen","Brewery":"Bavaria","Country":"Colombia","Price":2500.5,"Currency":"COP"}`, string(bytes))
+}
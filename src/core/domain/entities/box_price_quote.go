@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/shopspring/decimal"
+)
+
+// BoxPriceQuote is the price of a box of beers in the requested currency.
+// UnitPrice is rounded for display, while Subtotal is computed from the exact
+// converted unit price. NetPrice is the Subtotal minus every discount line,
+// plus the PackagingCost when the box is a Pack, and TotalPrice is the
+// NetPrice plus every tax line of the Destination, so both are equal when no
+// destination was given. RateProvider and RateTimestamp are empty when no
+// conversion was needed.
+type BoxPriceQuote struct {
+	BeerID         int64
+	SourceCurrency string
+	TargetCurrency string
+	ExchangeRate   decimal.Decimal
+	UnitPrice      money.Money
+	Quantity       uint64
+	Subtotal       money.Money
+	Discounts      []DiscountLine
+	Pack           *Pack
+	PackagingCost  money.Money
+	Destination    string
+	NetPrice       money.Money
+	Taxes          []TaxLine
+	TotalPrice     money.Money
+	RateProvider   string
+	RateTimestamp  time.Time
+}
+
+// BoxPriceOptions are the optional inputs of a box price quote.
+// Pack is the size of one of the packs of the beer, used instead of a raw
+// quantity. Destination is the ISO 3166-1 alpha-2 code of the country the box
+// is sold into, used to add its taxes.
+type BoxPriceOptions struct {
+	Coupon      string
+	Pack        uint64
+	Destination string
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "github.com/dleonsal/beers-api/src/errors"
+
+// BoxPriceResult is the box price quote for one of several requested
+// currencies, or the error that prevented quoting it.
+type BoxPriceResult struct {
+	Currency string
+	Quote    *BoxPriceQuote
+	Err      *errors.RestError
+}
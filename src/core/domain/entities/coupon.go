@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Coupon types. A percentage coupon takes Percentage off the box, while a
+// fixed coupon takes Amount in Currency off it.
+const (
+	PercentageCoupon = "percentage"
+	FixedCoupon      = "fixed"
+	maxCouponCodeLen = 32
+)
+
+// Coupon is a promotional code for the box price. ValidFrom, ValidUntil and
+// MaxUses are optional, a zero MaxUses means unlimited uses. BeerId, Brewery
+// and Country restrict the beers the coupon applies to when set.
+type Coupon struct {
+	Id         int64           `json:"Id"`
+	Code       string          `json:"Code"`
+	Type       string          `json:"Type"`
+	Percentage decimal.Decimal `json:"Percentage"`
+	Amount     decimal.Decimal `json:"Amount"`
+	Currency   string          `json:"Currency"`
+	ValidFrom  *time.Time      `json:"ValidFrom"`
+	ValidUntil *time.Time      `json:"ValidUntil"`
+	MaxUses    uint64          `json:"MaxUses"`
+	Uses       uint64          `json:"Uses"`
+	BeerId     *int64          `json:"BeerId"`
+	Brewery    string          `json:"Brewery"`
+	Country    string          `json:"Country"`
+}
+
+type couponJSON struct {
+	Id         int64           `json:"Id"`
+	Code       string          `json:"Code"`
+	Type       string          `json:"Type"`
+	Percentage json.RawMessage `json:"Percentage"`
+	Amount     json.RawMessage `json:"Amount"`
+	Currency   string          `json:"Currency"`
+	ValidFrom  *time.Time      `json:"ValidFrom"`
+	ValidUntil *time.Time      `json:"ValidUntil"`
+	MaxUses    uint64          `json:"MaxUses"`
+	Uses       uint64          `json:"Uses"`
+	BeerId     *int64          `json:"BeerId"`
+	Brewery    string          `json:"Brewery"`
+	Country    string          `json:"Country"`
+}
+
+// MarshalJSON writes the percentage and the amount as JSON numbers.
+func (c Coupon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(couponJSON{
+		Id:         c.Id,
+		Code:       c.Code,
+		Type:       c.Type,
+		Percentage: money.JSONNumber(c.Percentage),
+		Amount:     money.JSONNumber(c.Amount),
+		Currency:   c.Currency,
+		ValidFrom:  c.ValidFrom,
+		ValidUntil: c.ValidUntil,
+		MaxUses:    c.MaxUses,
+		Uses:       c.Uses,
+		BeerId:     c.BeerId,
+		Brewery:    c.Brewery,
+		Country:    c.Country,
+	})
+}
+
+// NormalizeCouponCode trims the code and makes it upper case, so codes are
+// matched case insensitively.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the coupon fields and normalizes its code and currency.
+func (c *Coupon) Validate() *errors.RestError {
+	if c.Id < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Id: %d", c.Id))
+	}
+
+	c.Code = NormalizeCouponCode(c.Code)
+	if len(c.Code) == 0 || len(c.Code) > maxCouponCodeLen {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Code: %s, it must have between 1 and %d characters", c.Code, maxCouponCodeLen))
+	}
+
+	switch c.Type {
+	case PercentageCoupon:
+		if c.Percentage.Sign() <= 0 || c.Percentage.GreaterThan(decimal.NewFromInt(maxDiscountPercentage)) {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Percentage: %s, it must be greater than 0 and at most %d", c.Percentage, maxDiscountPercentage))
+		}
+	case FixedCoupon:
+		if c.Amount.Sign() <= 0 {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Amount: %s", c.Amount))
+		}
+
+		currencyCode, err := currency.Normalize(c.Currency)
+		if err != nil {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Currency: %s", c.Currency))
+		}
+		c.Currency = currencyCode
+	default:
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Type: %s, it must be %s or %s", c.Type, PercentageCoupon, FixedCoupon))
+	}
+
+	if c.ValidFrom != nil && c.ValidUntil != nil && c.ValidUntil.Before(*c.ValidFrom) {
+		return errors.NewBadRequestError("ValidUntil must not be before ValidFrom")
+	}
+
+	if c.BeerId != nil && *c.BeerId <= 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid BeerId: %d", *c.BeerId))
+	}
+
+	return nil
+}
+
+// CheckRedeemable returns an error when the coupon can not be used at the given
+// time, either because it is outside its validity window or it has no uses
+// left.
+func (c *Coupon) CheckRedeemable(now time.Time) *errors.RestError {
+	if c.ValidFrom != nil && now.Before(*c.ValidFrom) {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("coupon %s is not valid yet", c.Code))
+	}
+
+	if c.ValidUntil != nil && now.After(*c.ValidUntil) {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("coupon %s has expired", c.Code))
+	}
+
+	if c.MaxUses != 0 && c.Uses >= c.MaxUses {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("coupon %s has reached its usage limit", c.Code))
+	}
+
+	return nil
+}
+
+// CheckApplicable returns an error when the coupon can not be used at the given
+// time or its scope does not include the beer.
+func (c *Coupon) CheckApplicable(beer *Beer, now time.Time) *errors.RestError {
+	if err := c.CheckRedeemable(now); err != nil {
+		return err
+	}
+
+	if (c.BeerId != nil && *c.BeerId != beer.Id) ||
+		(c.Brewery != "" && !strings.EqualFold(c.Brewery, beer.Brewery)) ||
+		(c.Country != "" && !strings.EqualFold(c.Country, beer.Country)) {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("coupon %s does not apply to beer %d", c.Code, beer.Id))
+	}
+
+	return nil
+}
+
+// FixedAmount returns the discount of a fixed coupon in its own currency.
+func (c *Coupon) FixedAmount() money.Money {
+	return money.New(c.Amount, c.Currency)
+}
@@ -0,0 +1,105 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CouponValidate_WhenCodeIsEmpty_ThenReturnBadRequestError(t *testing.T) {
+	coupon := entities.Coupon{Code: "  ", Type: entities.PercentageCoupon, Percentage: decimal.NewFromInt(10)}
+	expectedError := errors.NewBadRequestError("invalid Code: , it must have between 1 and 32 characters")
+
+	err := coupon.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CouponValidate_WhenFixedCouponHasInvalidCurrency_ThenReturnBadRequestError(t *testing.T) {
+	coupon := entities.Coupon{Code: "summer", Type: entities.FixedCoupon, Amount: decimal.NewFromInt(5), Currency: "XYZ1"}
+	expectedError := errors.NewBadRequestError("invalid Currency: XYZ1")
+
+	err := coupon.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CouponValidate_WhenValidUntilIsBeforeValidFrom_ThenReturnBadRequestError(t *testing.T) {
+	validFrom := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	validUntil := validFrom.Add(-time.Hour)
+	coupon := entities.Coupon{
+		Code:       "summer",
+		Type:       entities.PercentageCoupon,
+		Percentage: decimal.NewFromInt(10),
+		ValidFrom:  &validFrom,
+		ValidUntil: &validUntil,
+	}
+	expectedError := errors.NewBadRequestError("ValidUntil must not be before ValidFrom")
+
+	err := coupon.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CouponValidate_WhenCouponIsValid_ThenNormalizeCodeAndCurrency(t *testing.T) {
+	coupon := entities.Coupon{Code: " summer ", Type: entities.FixedCoupon, Amount: decimal.NewFromInt(5), Currency: "usd"}
+
+	err := coupon.Validate()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "SUMMER", coupon.Code)
+	assert.Equal(t, "USD", coupon.Currency)
+}
+
+func Test_CheckApplicable_WhenCouponIsNotValidYet_ThenReturnBadRequestError(t *testing.T) {
+	now := time.Date(2021, 11, 19, 0, 0, 0, 0, time.UTC)
+	validFrom := now.Add(time.Hour)
+	coupon := entities.Coupon{Code: "SUMMER", ValidFrom: &validFrom}
+	expectedError := errors.NewBadRequestError("coupon SUMMER is not valid yet")
+
+	err := coupon.CheckApplicable(&entities.Beer{Id: 1}, now)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CheckApplicable_WhenCouponHasExpired_ThenReturnBadRequestError(t *testing.T) {
+	now := time.Date(2021, 11, 19, 0, 0, 0, 0, time.UTC)
+	validUntil := now.Add(-time.Hour)
+	coupon := entities.Coupon{Code: "SUMMER", ValidUntil: &validUntil}
+	expectedError := errors.NewBadRequestError("coupon SUMMER has expired")
+
+	err := coupon.CheckApplicable(&entities.Beer{Id: 1}, now)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CheckApplicable_WhenCouponReachedUsageLimit_ThenReturnBadRequestError(t *testing.T) {
+	coupon := entities.Coupon{Code: "SUMMER", MaxUses: 3, Uses: 3}
+	expectedError := errors.NewBadRequestError("coupon SUMMER has reached its usage limit")
+
+	err := coupon.CheckApplicable(&entities.Beer{Id: 1}, time.Now())
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CheckApplicable_WhenBeerIsOutOfScope_ThenReturnBadRequestError(t *testing.T) {
+	coupon := entities.Coupon{Code: "SUMMER", Brewery: "Bavaria"}
+	expectedError := errors.NewBadRequestError("coupon SUMMER does not apply to beer 1")
+
+	err := coupon.CheckApplicable(&entities.Beer{Id: 1, Brewery: "Heineken"}, time.Now())
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CheckApplicable_WhenBeerIsInScope_ThenReturnNil(t *testing.T) {
+	beerID := int64(1)
+	coupon := entities.Coupon{Code: "SUMMER", BeerId: &beerID, Brewery: "bavaria", Country: "Colombia", MaxUses: 3, Uses: 2}
+
+	err := coupon.CheckApplicable(&entities.Beer{Id: 1, Brewery: "Bavaria", Country: "Colombia"}, time.Now())
+
+	assert.Nil(t, err)
+}
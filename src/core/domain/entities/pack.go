@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Pack is a packaging a beer is sold in, such as a 6-pack or a 24-case crate.
+// Size is the number of beers it holds and Surcharge, in Currency, the cost of
+// the packaging itself. A beer has at most one pack of every size.
+type Pack struct {
+	Id        int64           `json:"Id"`
+	BeerId    int64           `json:"BeerId"`
+	Name      string          `json:"Name"`
+	Size      uint64          `json:"Size"`
+	Surcharge decimal.Decimal `json:"Surcharge"`
+	Currency  string          `json:"Currency"`
+}
+
+type packJSON struct {
+	Id        int64           `json:"Id"`
+	BeerId    int64           `json:"BeerId"`
+	Name      string          `json:"Name"`
+	Size      uint64          `json:"Size"`
+	Surcharge json.RawMessage `json:"Surcharge"`
+	Currency  string          `json:"Currency"`
+}
+
+// MarshalJSON writes the surcharge as a JSON number.
+func (p Pack) MarshalJSON() ([]byte, error) {
+	return json.Marshal(packJSON{
+		Id:        p.Id,
+		BeerId:    p.BeerId,
+		Name:      p.Name,
+		Size:      p.Size,
+		Surcharge: money.JSONNumber(p.Surcharge),
+		Currency:  p.Currency,
+	})
+}
+
+// Validate checks the pack fields and normalizes its currency.
+func (p *Pack) Validate() *errors.RestError {
+	if p.Id < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Id: %d", p.Id))
+	}
+
+	if p.BeerId <= 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid BeerId: %d", p.BeerId))
+	}
+
+	if len(strings.TrimSpace(p.Name)) == 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Name: %s", p.Name))
+	}
+
+	if p.Size == 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Size: %d", p.Size))
+	}
+
+	if p.Surcharge.Sign() < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Surcharge: %s", p.Surcharge))
+	}
+
+	currencyCode, err := currency.Normalize(p.Currency)
+	if err != nil {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Currency: %s", p.Currency))
+	}
+	p.Currency = currencyCode
+
+	return nil
+}
+
+// SurchargeAmount returns the cost of the packaging in its own currency.
+func (p *Pack) SurchargeAmount() money.Money {
+	return money.New(p.Surcharge, p.Currency)
+}
@@ -0,0 +1,37 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidatePack_WhenSizeIsZero_ThenReturnBadRequestError(t *testing.T) {
+	pack := entities.Pack{BeerId: 1, Name: "crate", Currency: "COP"}
+	expectedError := errors.NewBadRequestError("invalid Size: 0")
+
+	err := pack.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ValidatePack_WhenSurchargeIsNegative_ThenReturnBadRequestError(t *testing.T) {
+	pack := entities.Pack{BeerId: 1, Name: "crate", Size: 24, Surcharge: decimal.NewFromInt(-1), Currency: "COP"}
+	expectedError := errors.NewBadRequestError("invalid Surcharge: -1")
+
+	err := pack.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ValidatePack_WhenPackIsValid_ThenNormalizeCurrency(t *testing.T) {
+	pack := entities.Pack{BeerId: 1, Name: "crate", Size: 24, Currency: "cop"}
+
+	err := pack.Validate()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "COP", pack.Currency)
+}
@@ -0,0 +1,167 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Pricing rule types. A percentage rule takes Percentage off the box once it
+// has at least MinQuantity beers. A buy N get one rule gives one beer for free
+// for every BuyQuantity beers paid.
+const (
+	PercentagePricingRule = "percentage"
+	BuyNGetOnePricingRule = "buy_n_get_one"
+	maxDiscountPercentage = 100
+)
+
+// PricingRule is a volume discount for a single beer, or for every beer when
+// BeerId is nil.
+type PricingRule struct {
+	Id          int64           `json:"Id"`
+	BeerId      *int64          `json:"BeerId"`
+	Name        string          `json:"Name"`
+	Type        string          `json:"Type"`
+	MinQuantity uint64          `json:"MinQuantity"`
+	Percentage  decimal.Decimal `json:"Percentage"`
+	BuyQuantity uint64          `json:"BuyQuantity"`
+}
+
+type pricingRuleJSON struct {
+	Id          int64           `json:"Id"`
+	BeerId      *int64          `json:"BeerId"`
+	Name        string          `json:"Name"`
+	Type        string          `json:"Type"`
+	MinQuantity uint64          `json:"MinQuantity"`
+	Percentage  json.RawMessage `json:"Percentage"`
+	BuyQuantity uint64          `json:"BuyQuantity"`
+}
+
+// MarshalJSON writes the percentage as a JSON number.
+func (r PricingRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pricingRuleJSON{
+		Id:          r.Id,
+		BeerId:      r.BeerId,
+		Name:        r.Name,
+		Type:        r.Type,
+		MinQuantity: r.MinQuantity,
+		Percentage:  money.JSONNumber(r.Percentage),
+		BuyQuantity: r.BuyQuantity,
+	})
+}
+
+// DiscountLine is a discount applied to a box price quote by either a pricing
+// rule or a coupon.
+type DiscountLine struct {
+	RuleID      int64
+	CouponCode  string
+	Description string
+	Amount      money.Money
+}
+
+func (r *PricingRule) Validate() *errors.RestError {
+	if r.Id < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Id: %d", r.Id))
+	}
+
+	if r.BeerId != nil && *r.BeerId <= 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid BeerId: %d", *r.BeerId))
+	}
+
+	if len(strings.TrimSpace(r.Name)) == 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Name: %s", r.Name))
+	}
+
+	switch r.Type {
+	case PercentagePricingRule:
+		if r.MinQuantity == 0 {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid MinQuantity: %d", r.MinQuantity))
+		}
+
+		if r.Percentage.Sign() <= 0 || r.Percentage.GreaterThan(decimal.NewFromInt(maxDiscountPercentage)) {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Percentage: %s, it must be greater than 0 and at most %d", r.Percentage, maxDiscountPercentage))
+		}
+	case BuyNGetOnePricingRule:
+		if r.BuyQuantity == 0 {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid BuyQuantity: %d", r.BuyQuantity))
+		}
+	default:
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Type: %s, it must be %s or %s", r.Type, PercentagePricingRule, BuyNGetOnePricingRule))
+	}
+
+	return nil
+}
+
+// ApplyPricingRules returns the discount lines for a box of quantity beers
+// priced at unitPrice each. Tiers do not stack: only the best rule of each type
+// is applied. The buy N get one discount is taken first and the percentage
+// discount is computed on what is left. Every line is rounded to the minor
+// units of the currency.
+func ApplyPricingRules(unitPrice money.Money, quantity uint64, rules []PricingRule) []DiscountLine {
+	discounts := make([]DiscountLine, 0)
+	subtotal := unitPrice.Mul(quantity).Round()
+
+	if rule := bestBuyNGetOneRule(rules, quantity); rule != nil {
+		freeBeers := quantity / (rule.BuyQuantity + 1)
+		amount := unitPrice.Mul(freeBeers).Round()
+		discounts = append(discounts, DiscountLine{
+			RuleID:      rule.Id,
+			Description: fmt.Sprintf("%s: %d free", rule.Name, freeBeers),
+			Amount:      amount,
+		})
+		subtotal = subtotal.Sub(amount)
+	}
+
+	if rule := bestPercentageRule(rules, quantity); rule != nil {
+		discounts = append(discounts, DiscountLine{
+			RuleID:      rule.Id,
+			Description: fmt.Sprintf("%s: %s%% off", rule.Name, rule.Percentage),
+			Amount:      subtotal.Percent(rule.Percentage).Round(),
+		})
+	}
+
+	return discounts
+}
+
+func bestBuyNGetOneRule(rules []PricingRule, quantity uint64) *PricingRule {
+	var best *PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Type != BuyNGetOnePricingRule || quantity <= rule.BuyQuantity {
+			continue
+		}
+
+		if best == nil || rule.BuyQuantity < best.BuyQuantity {
+			best = rule
+		}
+	}
+
+	return best
+}
+
+func bestPercentageRule(rules []PricingRule, quantity uint64) *PricingRule {
+	var best *PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Type != PercentagePricingRule || quantity < rule.MinQuantity {
+			continue
+		}
+
+		if best == nil || rule.Percentage.GreaterThan(best.Percentage) {
+			best = rule
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,131 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PricingRuleValidate_WhenBeerIdIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	beerID := int64(0)
+	rule := entities.PricingRule{BeerId: &beerID, Name: "volume", Type: entities.BuyNGetOnePricingRule, BuyQuantity: 5}
+	expectedError := errors.NewBadRequestError("invalid BeerId: 0")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_PricingRuleValidate_WhenNameIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.PricingRule{Name: " ", Type: entities.BuyNGetOnePricingRule, BuyQuantity: 5}
+	expectedError := errors.NewBadRequestError("invalid Name:  ")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_PricingRuleValidate_WhenTypeIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.PricingRule{Name: "volume", Type: "free"}
+	expectedError := errors.NewBadRequestError("invalid Type: free, it must be percentage or buy_n_get_one")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_PricingRuleValidate_WhenPercentageIsOutOfRange_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.PricingRule{
+		Name:        "volume",
+		Type:        entities.PercentagePricingRule,
+		MinQuantity: 12,
+		Percentage:  decimal.NewFromInt(101),
+	}
+	expectedError := errors.NewBadRequestError("invalid Percentage: 101, it must be greater than 0 and at most 100")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_PricingRuleValidate_WhenMinQuantityIsZero_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.PricingRule{Name: "volume", Type: entities.PercentagePricingRule, Percentage: decimal.NewFromInt(5)}
+	expectedError := errors.NewBadRequestError("invalid MinQuantity: 0")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_PricingRuleValidate_WhenBuyQuantityIsZero_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.PricingRule{Name: "promo", Type: entities.BuyNGetOnePricingRule}
+	expectedError := errors.NewBadRequestError("invalid BuyQuantity: 0")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ApplyPricingRules_WhenSeveralTiersApply_ThenApplyOnlyTheBestOne(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("2.50"), "USD")
+	rules := []entities.PricingRule{
+		givenPercentageRule(1, 12, "5"),
+		givenPercentageRule(2, 24, "10"),
+	}
+
+	discounts := entities.ApplyPricingRules(unitPrice, 24, rules)
+
+	assert.Len(t, discounts, 1)
+	assert.Equal(t, int64(2), discounts[0].RuleID)
+	assert.Equal(t, "volume: 10% off", discounts[0].Description)
+	assert.True(t, money.New(decimal.RequireFromString("6.00"), "USD").Equal(discounts[0].Amount))
+}
+
+func Test_ApplyPricingRules_WhenQuantityIsBelowEveryTier_ThenReturnNoDiscounts(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("2.50"), "USD")
+	rules := []entities.PricingRule{givenPercentageRule(1, 12, "5")}
+
+	discounts := entities.ApplyPricingRules(unitPrice, 6, rules)
+
+	assert.Empty(t, discounts)
+}
+
+func Test_ApplyPricingRules_WhenBuyNGetOneAndPercentageApply_ThenTakePercentageOnTheRest(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("2.50"), "USD")
+	rules := []entities.PricingRule{
+		givenPercentageRule(1, 12, "10"),
+		{Id: 3, Name: "promo", Type: entities.BuyNGetOnePricingRule, BuyQuantity: 5},
+	}
+
+	discounts := entities.ApplyPricingRules(unitPrice, 12, rules)
+
+	assert.Len(t, discounts, 2)
+	assert.Equal(t, int64(3), discounts[0].RuleID)
+	assert.Equal(t, "promo: 2 free", discounts[0].Description)
+	assert.True(t, money.New(decimal.RequireFromString("5.00"), "USD").Equal(discounts[0].Amount))
+	assert.Equal(t, int64(1), discounts[1].RuleID)
+	assert.True(t, money.New(decimal.RequireFromString("2.50"), "USD").Equal(discounts[1].Amount))
+}
+
+func Test_ApplyPricingRules_WhenDiscountHasMoreDecimalsThanCurrency_ThenRoundIt(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("2.99"), "USD")
+	rules := []entities.PricingRule{givenPercentageRule(1, 12, "5")}
+
+	discounts := entities.ApplyPricingRules(unitPrice, 12, rules)
+
+	assert.True(t, money.New(decimal.RequireFromString("1.79"), "USD").Equal(discounts[0].Amount))
+}
+
+func givenPercentageRule(id int64, minQuantity uint64, percentage string) entities.PricingRule {
+	return entities.PricingRule{
+		Id:          id,
+		Name:        "volume",
+		Type:        entities.PercentagePricingRule,
+		MinQuantity: minQuantity,
+		Percentage:  decimal.RequireFromString(percentage),
+	}
+}
@@ -0,0 +1,151 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Tax rule categories. General rules apply to every product sold into the
+// country, while beer rules only apply to beers.
+const (
+	GeneralTaxCategory = "general"
+	BeerTaxCategory    = "beer"
+)
+
+// Tax rule types. A percentage tax, such as VAT, charges Percentage of the
+// price, while a per unit tax, such as the excise on beer, charges Amount in
+// Currency for every beer.
+const (
+	PercentageTax    = "percentage"
+	PerUnitTax       = "per_unit"
+	maxTaxPercentage = 100
+	countryCodeLen   = 2
+)
+
+// TaxRule is a tax charged on the products of a category sold into Country,
+// an ISO 3166-1 alpha-2 code.
+type TaxRule struct {
+	Id         int64           `json:"Id"`
+	Country    string          `json:"Country"`
+	Category   string          `json:"Category"`
+	Name       string          `json:"Name"`
+	Type       string          `json:"Type"`
+	Percentage decimal.Decimal `json:"Percentage"`
+	Amount     decimal.Decimal `json:"Amount"`
+	Currency   string          `json:"Currency"`
+}
+
+type taxRuleJSON struct {
+	Id         int64           `json:"Id"`
+	Country    string          `json:"Country"`
+	Category   string          `json:"Category"`
+	Name       string          `json:"Name"`
+	Type       string          `json:"Type"`
+	Percentage json.RawMessage `json:"Percentage"`
+	Amount     json.RawMessage `json:"Amount"`
+	Currency   string          `json:"Currency"`
+}
+
+// MarshalJSON writes the percentage and the amount as JSON numbers.
+func (r TaxRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(taxRuleJSON{
+		Id:         r.Id,
+		Country:    r.Country,
+		Category:   r.Category,
+		Name:       r.Name,
+		Type:       r.Type,
+		Percentage: money.JSONNumber(r.Percentage),
+		Amount:     money.JSONNumber(r.Amount),
+		Currency:   r.Currency,
+	})
+}
+
+// TaxLine is a tax charged on a box price quote by a tax rule.
+type TaxLine struct {
+	RuleID      int64
+	Description string
+	Amount      money.Money
+}
+
+// NormalizeCountryCode trims the code and makes it upper case. It returns
+// false when the result is not a two letter code.
+func NormalizeCountryCode(code string) (string, bool) {
+	normalizedCode := strings.ToUpper(strings.TrimSpace(code))
+	if len(normalizedCode) != countryCodeLen {
+		return "", false
+	}
+
+	for _, letter := range normalizedCode {
+		if letter < 'A' || letter > 'Z' {
+			return "", false
+		}
+	}
+
+	return normalizedCode, true
+}
+
+// Validate checks the tax rule fields and normalizes its country and currency.
+func (r *TaxRule) Validate() *errors.RestError {
+	if r.Id < 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Id: %d", r.Id))
+	}
+
+	country, ok := NormalizeCountryCode(r.Country)
+	if !ok {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Country: %s, it must be an ISO 3166-1 alpha-2 code", r.Country))
+	}
+	r.Country = country
+
+	if r.Category != GeneralTaxCategory && r.Category != BeerTaxCategory {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Category: %s, it must be %s or %s", r.Category, GeneralTaxCategory, BeerTaxCategory))
+	}
+
+	if len(strings.TrimSpace(r.Name)) == 0 {
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Name: %s", r.Name))
+	}
+
+	switch r.Type {
+	case PercentageTax:
+		if r.Percentage.Sign() <= 0 || r.Percentage.GreaterThan(decimal.NewFromInt(maxTaxPercentage)) {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Percentage: %s, it must be greater than 0 and at most %d", r.Percentage, maxTaxPercentage))
+		}
+	case PerUnitTax:
+		if r.Amount.Sign() <= 0 {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Amount: %s", r.Amount))
+		}
+
+		currencyCode, err := currency.Normalize(r.Currency)
+		if err != nil {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid Currency: %s", r.Currency))
+		}
+		r.Currency = currencyCode
+	default:
+		return errors.NewBadRequestError(
+			fmt.Sprintf("invalid Type: %s, it must be %s or %s", r.Type, PercentageTax, PerUnitTax))
+	}
+
+	return nil
+}
+
+// AppliesTo reports whether the rule taxes products of the category.
+func (r *TaxRule) AppliesTo(category string) bool {
+	return r.Category == GeneralTaxCategory || r.Category == category
+}
+
+// UnitAmount returns the amount a per unit tax charges for every beer.
+func (r *TaxRule) UnitAmount() money.Money {
+	return money.New(r.Amount, r.Currency)
+}
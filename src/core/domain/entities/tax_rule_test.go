@@ -0,0 +1,72 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeCountryCode_WhenCodeIsNotTwoLetters_ThenReturnFalse(t *testing.T) {
+	for _, code := range []string{"", "C", "COL", "C1", "Colombia"} {
+		_, ok := entities.NormalizeCountryCode(code)
+
+		assert.False(t, ok, code)
+	}
+}
+
+func Test_NormalizeCountryCode_WhenCodeIsLowerCase_ThenReturnUpperCase(t *testing.T) {
+	code, ok := entities.NormalizeCountryCode(" co ")
+
+	assert.True(t, ok)
+	assert.Equal(t, "CO", code)
+}
+
+func Test_ValidateTaxRule_WhenCategoryIsInvalid_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.TaxRule{Country: "CO", Category: "wine", Name: "IVA", Type: entities.PercentageTax, Percentage: decimal.NewFromInt(19)}
+	expectedError := errors.NewBadRequestError("invalid Category: wine, it must be general or beer")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ValidateTaxRule_WhenPercentageIsGreaterThan100_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.TaxRule{Country: "CO", Category: entities.GeneralTaxCategory, Name: "IVA", Type: entities.PercentageTax, Percentage: decimal.NewFromInt(101)}
+	expectedError := errors.NewBadRequestError("invalid Percentage: 101, it must be greater than 0 and at most 100")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ValidateTaxRule_WhenPerUnitTaxHasNoCurrency_ThenReturnBadRequestError(t *testing.T) {
+	rule := entities.TaxRule{Country: "DE", Category: entities.BeerTaxCategory, Name: "Biersteuer", Type: entities.PerUnitTax, Amount: decimal.RequireFromString("0.05")}
+	expectedError := errors.NewBadRequestError("invalid Currency: ")
+
+	err := rule.Validate()
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ValidateTaxRule_WhenRuleIsValid_ThenNormalizeCountryAndCurrency(t *testing.T) {
+	rule := entities.TaxRule{Country: "de", Category: entities.BeerTaxCategory, Name: "Biersteuer", Type: entities.PerUnitTax, Amount: decimal.RequireFromString("0.05"), Currency: "eur"}
+
+	err := rule.Validate()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DE", rule.Country)
+	assert.Equal(t, "EUR", rule.Currency)
+}
+
+func Test_AppliesTo_WhenRuleIsGeneral_ThenApplyToEveryCategory(t *testing.T) {
+	general := entities.TaxRule{Category: entities.GeneralTaxCategory}
+	beer := entities.TaxRule{Category: entities.BeerTaxCategory}
+
+	assert.True(t, general.AppliesTo(entities.BeerTaxCategory))
+	assert.True(t, general.AppliesTo("wine"))
+	assert.True(t, beer.AppliesTo(entities.BeerTaxCategory))
+	assert.False(t, beer.AppliesTo("wine"))
+}
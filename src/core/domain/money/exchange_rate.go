@@ -0,0 +1,22 @@
+package money
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate is the number of units of the To currency worth one unit of the
+// From currency, as served by Provider at Timestamp.
+type ExchangeRate struct {
+	From      string
+	To        string
+	Rate      decimal.Decimal
+	Provider  string
+	Timestamp time.Time
+}
+
+// Apply converts the value to the To currency without rounding.
+func (r ExchangeRate) Apply(value Money) Money {
+	return value.Convert(r.Rate, r.To)
+}
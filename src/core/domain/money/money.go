@@ -0,0 +1,122 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/shopspring/decimal"
+)
+
+const defaultMinorUnits = 2
+
+// Money is an exact decimal amount in a given currency.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+type moneyJSON struct {
+	Amount   json.RawMessage `json:"Amount"`
+	Currency string          `json:"Currency"`
+}
+
+func New(amount decimal.Decimal, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// MinorUnits returns the number of decimal places used by the currency,
+// falling back to two for codes outside the ISO 4217 registry.
+func MinorUnits(code string) int32 {
+	if currency, ok := currency.Lookup(strings.ToUpper(code)); ok {
+		return currency.MinorUnits
+	}
+
+	return defaultMinorUnits
+}
+
+// Mul multiplies the amount by the given quantity without rounding. The whole
+// uint64 range is supported, so big quantities do not wrap around.
+func (m Money) Mul(quantity uint64) Money {
+	return New(m.Amount.Mul(decimal.NewFromBigInt(new(big.Int).SetUint64(quantity), 0)), m.Currency)
+}
+
+// Add returns the sum of both amounts. It panics when the currencies differ,
+// since amounts must be converted before they are combined.
+func (m Money) Add(other Money) Money {
+	m.mustMatchCurrency(other, "add")
+	return New(m.Amount.Add(other.Amount), m.Currency)
+}
+
+// Sub returns the difference of both amounts. It panics when the currencies
+// differ, since amounts must be converted before they are combined.
+func (m Money) Sub(other Money) Money {
+	m.mustMatchCurrency(other, "subtract")
+	return New(m.Amount.Sub(other.Amount), m.Currency)
+}
+
+func (m Money) mustMatchCurrency(other Money, operation string) {
+	if m.Currency != other.Currency {
+		panic(fmt.Sprintf("money: cannot %s %s and %s amounts", operation, m.Currency, other.Currency))
+	}
+}
+
+// Percent returns the given percentage of the amount without rounding.
+func (m Money) Percent(percentage decimal.Decimal) Money {
+	return New(m.Amount.Mul(percentage).Div(decimal.NewFromInt(100)), m.Currency)
+}
+
+// Convert applies the exchange rate to the amount without rounding and returns
+// the result in the new currency.
+func (m Money) Convert(rate decimal.Decimal, newCurrency string) Money {
+	return New(m.Amount.Mul(rate), newCurrency)
+}
+
+// Round rounds the amount half away from zero to the minor units of its currency.
+func (m Money) Round() Money {
+	return New(m.Amount.Round(MinorUnits(m.Currency)), m.Currency)
+}
+
+func (m Money) Equal(other Money) bool {
+	return m.Currency == other.Currency && m.Amount.Equal(other.Amount)
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Amount.StringFixed(MinorUnits(m.Currency)), m.Currency)
+}
+
+// JSONNumber returns the value as a JSON number. Decimals are marshaled as
+// strings by default, so the API types with decimal fields use it to expose
+// them as numbers.
+func JSONNumber(value decimal.Decimal) json.RawMessage {
+	return json.RawMessage(value.String())
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Amount:   json.RawMessage(m.Amount.StringFixed(MinorUnits(m.Currency))),
+		Currency: m.Currency,
+	})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	var amount decimal.Decimal
+	if err := amount.UnmarshalJSON(value.Amount); err != nil {
+		return err
+	}
+
+	m.Amount = amount
+	m.Currency = value.Currency
+
+	return nil
+}
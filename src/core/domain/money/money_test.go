@@ -0,0 +1,129 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mul_WhenAmountHasDecimals_ThenReturnExactAmount(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("5.95"), "USD")
+
+	totalPrice := unitPrice.Mul(6)
+
+	assert.Equal(t, "35.7", totalPrice.Amount.String())
+	assert.Equal(t, "USD", totalPrice.Currency)
+}
+
+func Test_Mul_WhenQuantityIsAboveMaxInt64_ThenReturnPositiveAmount(t *testing.T) {
+	unitPrice := money.New(decimal.RequireFromString("2"), "USD")
+
+	totalPrice := unitPrice.Mul(math.MaxUint64)
+
+	assert.Equal(t, "36893488147419103230", totalPrice.Amount.String())
+}
+
+func Test_Sub_WhenBothAmountsHaveSameCurrency_ThenReturnDifference(t *testing.T) {
+	value := money.New(decimal.RequireFromString("30.00"), "USD")
+
+	result := value.Sub(money.New(decimal.RequireFromString("1.25"), "USD"))
+
+	assert.True(t, money.New(decimal.RequireFromString("28.75"), "USD").Equal(result))
+}
+
+func Test_Add_WhenCurrenciesDiffer_ThenPanic(t *testing.T) {
+	price := money.New(decimal.NewFromInt(10), "EUR")
+	other := money.New(decimal.NewFromInt(5), "USD")
+
+	assert.PanicsWithValue(t, "money: cannot add EUR and USD amounts", func() { price.Add(other) })
+}
+
+func Test_Sub_WhenCurrenciesDiffer_ThenPanic(t *testing.T) {
+	price := money.New(decimal.NewFromInt(10), "EUR")
+	other := money.New(decimal.NewFromInt(5), "USD")
+
+	assert.PanicsWithValue(t, "money: cannot subtract EUR and USD amounts", func() { price.Sub(other) })
+}
+
+func Test_Percent_WhenPercentageHasDecimals_ThenReturnExactAmount(t *testing.T) {
+	value := money.New(decimal.RequireFromString("35.70"), "USD")
+
+	result := value.Percent(decimal.RequireFromString("7.5"))
+
+	assert.True(t, decimal.RequireFromString("2.6775").Equal(result.Amount))
+	assert.Equal(t, "USD", result.Currency)
+}
+
+func Test_Convert_WhenRateIsApplied_ThenReturnAmountInNewCurrency(t *testing.T) {
+	price := money.New(decimal.NewFromInt(2500), "COP")
+
+	convertedPrice := price.Convert(decimal.RequireFromString("0.00026"), "USD")
+
+	assert.Equal(t, "0.65", convertedPrice.Amount.String())
+	assert.Equal(t, "USD", convertedPrice.Currency)
+}
+
+func Test_Round_WhenCurrencyHasTwoMinorUnits_ThenRoundToCents(t *testing.T) {
+	price := money.New(decimal.RequireFromString("10.005"), "USD")
+
+	roundedPrice := price.Round()
+
+	assert.Equal(t, "10.01", roundedPrice.Amount.String())
+}
+
+func Test_Round_WhenCurrencyHasNoMinorUnits_ThenRoundToUnits(t *testing.T) {
+	price := money.New(decimal.RequireFromString("1234.5"), "JPY")
+
+	roundedPrice := price.Round()
+
+	assert.Equal(t, "1235", roundedPrice.Amount.String())
+}
+
+func Test_Round_WhenCurrencyHasThreeMinorUnits_ThenRoundToThreeDecimals(t *testing.T) {
+	price := money.New(decimal.RequireFromString("1.23456"), "KWD")
+
+	roundedPrice := price.Round()
+
+	assert.Equal(t, "1.235", roundedPrice.Amount.String())
+}
+
+func Test_MarshalJSON_WhenMoneyIsMarshaled_ThenAmountIsNumberWithMinorUnits(t *testing.T) {
+	price := money.New(decimal.RequireFromString("35.7"), "USD")
+
+	bytes, err := json.Marshal(price)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Amount":35.70,"Currency":"USD"}`, string(bytes))
+}
+
+func Test_JSONNumber_WhenDecimalIsMarshaled_ThenLeaveDecimalsAsStringsElsewhere(t *testing.T) {
+	value := decimal.RequireFromString("0.00026")
+
+	bytes, err := json.Marshal(value)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `"0.00026"`, string(bytes))
+	assert.Equal(t, `0.00026`, string(money.JSONNumber(value)))
+}
+
+func Test_UnmarshalJSON_WhenJSONIsValid_ThenReturnMoney(t *testing.T) {
+	expectedPrice := money.New(decimal.RequireFromString("35.7"), "USD")
+	price := money.Money{}
+
+	err := json.Unmarshal([]byte(`{"Amount":35.70,"Currency":"USD"}`), &price)
+
+	assert.Nil(t, err)
+	assert.True(t, expectedPrice.Equal(price))
+}
+
+func Test_UnmarshalJSON_WhenAmountIsInvalid_ThenReturnError(t *testing.T) {
+	price := money.Money{}
+
+	err := json.Unmarshal([]byte(`{"Amount":"abc","Currency":"USD"}`), &price)
+
+	assert.NotNil(t, err)
+}
@@ -1,41 +1,195 @@
 package services
 
 import (
-	"strings"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
 	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
 	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultBoxQuantity = uint64(6)
+	// maxBoxPriceCurrencies bounds the concurrent conversions of a single
+	// GetBoxPrices call.
+	maxBoxPriceCurrencies = 20
+	// importBatchSize is the number of beers saved in each transaction of a
+	// best effort import.
+	importBatchSize = 100
+	maxImportRows   = 5000
 )
 
 type BeerRepository interface {
-	List() ([]entities.Beer, *errors.RestError)
+	List(query entities.BeerQuery) ([]entities.Beer, *errors.RestError)
+	Count(query entities.BeerQuery) (int64, *errors.RestError)
+	// Stream calls fn with every beer matching the query filters as they are
+	// read, ignoring the pagination, and stops at the first error of fn.
+	Stream(query entities.BeerQuery, fn func(beer entities.Beer) *errors.RestError) *errors.RestError
+	ListCurrencies(query entities.BeerQuery) ([]string, *errors.RestError)
 	GetByID(beerID int64) (*entities.Beer, *errors.RestError)
-	Save(beer entities.Beer) *errors.RestError
+	Save(beer entities.Beer) (int64, *errors.RestError)
+	// SaveBatch saves the beers in a single transaction, rolled back when
+	// atomic and any beer fails.
+	SaveBatch(beers []entities.Beer, atomic bool) ([]entities.BeerSaveResult, *errors.RestError)
+	Update(beer entities.Beer) *errors.RestError
+	Delete(beerID int64) *errors.RestError
+}
+
+type PricingRuleRepository interface {
+	List() ([]entities.PricingRule, *errors.RestError)
+	// ListByBeer returns the rules of the beer together with the global ones.
+	ListByBeer(beerID int64) ([]entities.PricingRule, *errors.RestError)
+	GetByID(ruleID int64) (*entities.PricingRule, *errors.RestError)
+	Save(rule entities.PricingRule) (int64, *errors.RestError)
+	Update(rule entities.PricingRule) *errors.RestError
+	Delete(ruleID int64) *errors.RestError
+}
+
+type CouponRepository interface {
+	List() ([]entities.Coupon, *errors.RestError)
+	GetByID(couponID int64) (*entities.Coupon, *errors.RestError)
+	GetByCode(code string) (*entities.Coupon, *errors.RestError)
+	Save(coupon entities.Coupon) (int64, *errors.RestError)
+	Update(coupon entities.Coupon) *errors.RestError
+	Delete(couponID int64) *errors.RestError
+	// Redeem counts one use of the coupon unless it has reached MaxUses.
+	Redeem(couponID int64) *errors.RestError
+}
+
+type TaxRuleRepository interface {
+	List() ([]entities.TaxRule, *errors.RestError)
+	ListByCountry(country string) ([]entities.TaxRule, *errors.RestError)
+	GetByID(ruleID int64) (*entities.TaxRule, *errors.RestError)
+	Save(rule entities.TaxRule) (int64, *errors.RestError)
+	Update(rule entities.TaxRule) *errors.RestError
+	Delete(ruleID int64) *errors.RestError
+}
+
+type PackRepository interface {
+	List() ([]entities.Pack, *errors.RestError)
+	ListByBeer(beerID int64) ([]entities.Pack, *errors.RestError)
+	GetByID(packID int64) (*entities.Pack, *errors.RestError)
+	GetByBeerAndSize(beerID int64, size uint64) (*entities.Pack, *errors.RestError)
+	Save(pack entities.Pack) (int64, *errors.RestError)
+	Update(pack entities.Pack) *errors.RestError
+	Delete(packID int64) *errors.RestError
 }
 
 type CurrencyConverterClient interface {
-	ConvertValueToNewCurrency(oldCurrency, newCurrency string, value float64) (float64, *errors.RestError)
+	GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError)
 }
 
 type beerService struct {
 	beerRepository          BeerRepository
+	pricingRuleRepository   PricingRuleRepository
+	couponRepository        CouponRepository
+	taxRuleRepository       TaxRuleRepository
+	packRepository          PackRepository
 	currencyConverterClient CurrencyConverterClient
 }
 
-func NewBeerService(beerRepository BeerRepository, currencyConverterClient CurrencyConverterClient) *beerService {
+func NewBeerService(
+	beerRepository BeerRepository,
+	pricingRuleRepository PricingRuleRepository,
+	couponRepository CouponRepository,
+	taxRuleRepository TaxRuleRepository,
+	packRepository PackRepository,
+	currencyConverterClient CurrencyConverterClient,
+) *beerService {
 	return &beerService{
 		beerRepository:          beerRepository,
+		pricingRuleRepository:   pricingRuleRepository,
+		couponRepository:        couponRepository,
+		taxRuleRepository:       taxRuleRepository,
+		packRepository:          packRepository,
 		currencyConverterClient: currencyConverterClient,
 	}
 }
 
-func (s *beerService) ListBeers() ([]entities.Beer, *errors.RestError) {
-	beers, err := s.beerRepository.List()
+func (s *beerService) ListBeers(query entities.BeerQuery) ([]entities.Beer, int64, *errors.RestError) {
+	if err := query.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.beerRepository.Count(query)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return beers, nil
+	beers, err := s.beerRepository.List(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return beers, total, nil
+}
+
+// ExportBeers calls write with every beer matching the query filters, ignoring
+// the pagination. When newCurrency is set, every beer also gets its price
+// converted. The rates of the currencies in the catalog are fetched before the
+// first beer is written, so a failing conversion fails the export before it
+// starts.
+func (s *beerService) ExportBeers(query entities.BeerQuery, newCurrency string, write func(export entities.BeerExport) *errors.RestError) *errors.RestError {
+	if err := query.ValidateFilters(); err != nil {
+		return err
+	}
+
+	if newCurrency == "" {
+		return s.beerRepository.Stream(query, func(beer entities.Beer) *errors.RestError {
+			return write(entities.BeerExport{Beer: beer})
+		})
+	}
+
+	newCurrency, err := currency.Normalize(newCurrency)
+	if err != nil {
+		return err
+	}
+
+	currencies, err := s.beerRepository.ListCurrencies(query)
+	if err != nil {
+		return err
+	}
+
+	exchangeRates := make(map[string]*money.ExchangeRate, len(currencies))
+	for _, oldCurrency := range currencies {
+		if err := s.loadExchangeRate(exchangeRates, oldCurrency, newCurrency); err != nil {
+			return err
+		}
+	}
+
+	return s.beerRepository.Stream(query, func(beer entities.Beer) *errors.RestError {
+		// A beer saved after the currencies were listed may need a new rate.
+		if err := s.loadExchangeRate(exchangeRates, beer.Currency, newCurrency); err != nil {
+			return err
+		}
+
+		price := beer.UnitPrice()
+		if exchangeRate, ok := exchangeRates[beer.Currency]; ok {
+			price = exchangeRate.Apply(price)
+		}
+		price = price.Round()
+
+		return write(entities.BeerExport{Beer: beer, ConvertedPrice: &price})
+	})
+}
+
+func (s *beerService) loadExchangeRate(exchangeRates map[string]*money.ExchangeRate, oldCurrency, newCurrency string) *errors.RestError {
+	if _, ok := exchangeRates[oldCurrency]; ok || oldCurrency == newCurrency {
+		return nil
+	}
+
+	exchangeRate, err := s.currencyConverterClient.GetExchangeRate(oldCurrency, newCurrency)
+	if err != nil {
+		return err
+	}
+
+	exchangeRates[oldCurrency] = exchangeRate
+	return nil
 }
 
 func (s *beerService) GetBeerByID(beerID int64) (*entities.Beer, *errors.RestError) {
@@ -47,41 +201,459 @@ func (s *beerService) GetBeerByID(beerID int64) (*entities.Beer, *errors.RestErr
 	return beer, nil
 }
 
-func (s *beerService) GetBoxPrice(beerID int64, newCurrency string, quantity uint64) (float64, *errors.RestError) {
-	if len(strings.TrimSpace(newCurrency)) == 0 {
-		return 0, errors.NewBadRequestError("currency must not be empty")
+func (s *beerService) GetBoxPrice(beerID int64, newCurrency string, quantity uint64, options entities.BoxPriceOptions) (*entities.BoxPriceQuote, *errors.RestError) {
+	newCurrency, err := currency.Normalize(newCurrency)
+	if err != nil {
+		return nil, err
 	}
 
-	if quantity == uint64(0) {
-		quantity = 6
+	beer, err := s.GetBeerByID(beerID)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := s.loadBoxPricing(beer, quantity, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.quoteBoxPrice(beer, pricing, newCurrency)
+}
+
+// GetBoxPrices quotes the box price in every requested currency concurrently.
+// A currency that can not be quoted gets its own error in the results instead
+// of failing the whole request.
+func (s *beerService) GetBoxPrices(beerID int64, newCurrencies []string, quantity uint64, options entities.BoxPriceOptions) ([]entities.BoxPriceResult, *errors.RestError) {
+	if len(newCurrencies) == 0 {
+		return nil, errors.NewBadRequestError("currencies must not be empty")
+	}
+
+	if len(newCurrencies) > maxBoxPriceCurrencies {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("at most %d currencies can be requested", maxBoxPriceCurrencies))
 	}
 
 	beer, err := s.GetBeerByID(beerID)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	pricing, err := s.loadBoxPricing(beer, quantity, options)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.BoxPriceResult, len(newCurrencies))
+	var wg sync.WaitGroup
+	for i, newCurrency := range newCurrencies {
+		wg.Add(1)
+		go func(result *entities.BoxPriceResult, newCurrency string) {
+			defer wg.Done()
+
+			result.Currency = newCurrency
+			normalized, err := currency.Normalize(newCurrency)
+			if err != nil {
+				result.Err = err
+				return
+			}
+
+			result.Currency = normalized
+			result.Quote, result.Err = s.quoteBoxPrice(beer, pricing, normalized)
+		}(&results[i], newCurrency)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// boxPricing holds what a box price quote needs besides the beer, so it is
+// loaded once for every requested currency.
+type boxPricing struct {
+	quantity     uint64
+	pack         *entities.Pack
+	pricingRules []entities.PricingRule
+	coupon       *entities.Coupon
+	destination  string
+	taxRules     []entities.TaxRule
+}
+
+func (s *beerService) loadBoxPricing(beer *entities.Beer, quantity uint64, options entities.BoxPriceOptions) (*boxPricing, *errors.RestError) {
+	pricing := &boxPricing{quantity: boxQuantity(quantity)}
+	if options.Pack != 0 {
+		if quantity != 0 {
+			return nil, errors.NewBadRequestError("quantity and pack can not be used together")
+		}
+
+		pack, err := s.packRepository.GetByBeerAndSize(beer.Id, options.Pack)
+		if err != nil {
+			if err.Status == http.StatusNotFound {
+				return nil, errors.NewBadRequestError(
+					fmt.Sprintf("pack of %d is not available for beer %d", options.Pack, beer.Id))
+			}
+
+			return nil, err
+		}
+
+		pricing.pack = pack
+		pricing.quantity = pack.Size
+	}
+
+	pricingRules, err := s.pricingRuleRepository.ListByBeer(beer.Id)
+	if err != nil {
+		return nil, err
+	}
+	pricing.pricingRules = pricingRules
+
+	if options.Coupon != "" {
+		couponCode := entities.NormalizeCouponCode(options.Coupon)
+		coupon, err := s.couponRepository.GetByCode(couponCode)
+		if err != nil {
+			if err.Status == http.StatusNotFound {
+				return nil, errors.NewBadRequestError(fmt.Sprintf("invalid coupon: %s", couponCode))
+			}
+
+			return nil, err
+		}
+
+		if err := coupon.CheckApplicable(beer, time.Now()); err != nil {
+			return nil, err
+		}
+		pricing.coupon = coupon
+	}
+
+	if options.Destination != "" {
+		destination, ok := entities.NormalizeCountryCode(options.Destination)
+		if !ok {
+			return nil, errors.NewBadRequestError(
+				fmt.Sprintf("invalid destination: %s, it must be an ISO 3166-1 alpha-2 code", options.Destination))
+		}
+
+		taxRules, err := s.taxRuleRepository.ListByCountry(destination)
+		if err != nil {
+			return nil, err
+		}
+
+		pricing.destination = destination
+		for _, rule := range taxRules {
+			if rule.AppliesTo(entities.BeerTaxCategory) {
+				pricing.taxRules = append(pricing.taxRules, rule)
+			}
+		}
+	}
+
+	return pricing, nil
+}
+
+func (s *beerService) quoteBoxPrice(beer *entities.Beer, pricing *boxPricing, newCurrency string) (*entities.BoxPriceQuote, *errors.RestError) {
+	quantity := pricing.quantity
+	quote := &entities.BoxPriceQuote{
+		BeerID:         beer.Id,
+		SourceCurrency: beer.Currency,
+		TargetCurrency: newCurrency,
+		ExchangeRate:   decimal.NewFromInt(1),
+		Quantity:       quantity,
+	}
+	unitPrice := beer.UnitPrice()
+	if beer.Currency != newCurrency {
+		exchangeRate, err := s.currencyConverterClient.GetExchangeRate(beer.Currency, newCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		unitPrice = exchangeRate.Apply(unitPrice)
+		quote.ExchangeRate = exchangeRate.Rate
+		quote.RateProvider = exchangeRate.Provider
+		quote.RateTimestamp = exchangeRate.Timestamp
+	}
+
+	quote.UnitPrice = unitPrice.Round()
+	quote.Subtotal = unitPrice.Mul(quantity).Round()
+	quote.Discounts = entities.ApplyPricingRules(unitPrice, quantity, pricing.pricingRules)
+	quote.TotalPrice = quote.Subtotal
+	for _, discount := range quote.Discounts {
+		quote.TotalPrice = quote.TotalPrice.Sub(discount.Amount)
+	}
+
+	if pricing.coupon != nil {
+		discount, err := s.couponDiscount(pricing.coupon, quote.TotalPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		quote.Discounts = append(quote.Discounts, *discount)
+		quote.TotalPrice = quote.TotalPrice.Sub(discount.Amount)
+	}
+
+	if pricing.pack != nil {
+		packagingCost, err := s.convert(pricing.pack.SurchargeAmount(), newCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		quote.Pack = pricing.pack
+		quote.PackagingCost = packagingCost.Round()
+		quote.TotalPrice = quote.TotalPrice.Add(quote.PackagingCost)
+	}
+
+	quote.Destination = pricing.destination
+	quote.NetPrice = quote.TotalPrice
+	taxes, err := s.taxLines(pricing.taxRules, quote.NetPrice, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.Taxes = taxes
+	for _, tax := range quote.Taxes {
+		quote.TotalPrice = quote.TotalPrice.Add(tax.Amount)
+	}
+
+	return quote, nil
+}
+
+// taxLines computes the taxes of a box of quantity beers priced at net. Per
+// unit taxes are converted to the currency of the quote and charged first,
+// since percentage taxes such as VAT are charged on the price including them.
+func (s *beerService) taxLines(rules []entities.TaxRule, net money.Money, quantity uint64) ([]entities.TaxLine, *errors.RestError) {
+	taxes := make([]entities.TaxLine, 0, len(rules))
+	taxBase := net
+	for _, rule := range rules {
+		if rule.Type != entities.PerUnitTax {
+			continue
+		}
+
+		unitAmount, err := s.convert(rule.UnitAmount(), net.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		amount := unitAmount.Mul(quantity).Round()
+		taxes = append(taxes, entities.TaxLine{
+			RuleID:      rule.Id,
+			Description: fmt.Sprintf("%s: %s per unit", rule.Name, rule.UnitAmount()),
+			Amount:      amount,
+		})
+		taxBase = taxBase.Add(amount)
 	}
 
-	if beer.Currency == newCurrency {
-		totalPrice := beer.Price * float64(quantity)
+	for _, rule := range rules {
+		if rule.Type != entities.PercentageTax {
+			continue
+		}
 
-		return totalPrice, nil
+		taxes = append(taxes, entities.TaxLine{
+			RuleID:      rule.Id,
+			Description: fmt.Sprintf("%s: %s%%", rule.Name, rule.Percentage),
+			Amount:      taxBase.Percent(rule.Percentage).Round(),
+		})
 	}
 
-	newPrice, err := s.currencyConverterClient.ConvertValueToNewCurrency(beer.Currency, newCurrency, beer.Price)
+	return taxes, nil
+}
+
+// couponDiscount computes the coupon discount on what is left of the box price
+// after the pricing rules. A fixed amount is converted to the currency of the
+// quote and never takes the total below zero.
+func (s *beerService) couponDiscount(coupon *entities.Coupon, total money.Money) (*entities.DiscountLine, *errors.RestError) {
+	if coupon.Type == entities.PercentageCoupon {
+		return &entities.DiscountLine{
+			CouponCode:  coupon.Code,
+			Description: fmt.Sprintf("coupon %s: %s%% off", coupon.Code, coupon.Percentage),
+			Amount:      total.Percent(coupon.Percentage).Round(),
+		}, nil
+	}
+
+	amount, err := s.convert(coupon.FixedAmount(), total.Currency)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	totalPrice := newPrice * float64(quantity)
-	return totalPrice, nil
+	amount = amount.Round()
+	if amount.Amount.GreaterThan(total.Amount) {
+		amount = total
+	}
+
+	return &entities.DiscountLine{
+		CouponCode:  coupon.Code,
+		Description: fmt.Sprintf("coupon %s: %s off", coupon.Code, coupon.FixedAmount()),
+		Amount:      amount,
+	}, nil
 }
 
-func (s *beerService) CreateBeer(beer entities.Beer) *errors.RestError {
+// convert returns the exact amount in newCurrency.
+func (s *beerService) convert(amount money.Money, newCurrency string) (money.Money, *errors.RestError) {
+	if amount.Currency == newCurrency {
+		return amount, nil
+	}
+
+	exchangeRate, err := s.currencyConverterClient.GetExchangeRate(amount.Currency, newCurrency)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return exchangeRate.Apply(amount), nil
+}
+
+// boxQuantity returns the number of beers in a box, 6 when none is given.
+func boxQuantity(quantity uint64) uint64 {
+	if quantity == uint64(0) {
+		return defaultBoxQuantity
+	}
+
+	return quantity
+}
+
+func (s *beerService) CreateBeer(beer entities.Beer) (*entities.Beer, *errors.RestError) {
 	if err := beer.Validate(); err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := s.beerRepository.Save(beer); err != nil {
+	beerID, err := s.beerRepository.Save(beer)
+	if err != nil {
+		return nil, err
+	}
+
+	beer.Id = beerID
+	return &beer, nil
+}
+
+// ImportBeers validates every row and saves the valid ones. An all or nothing
+// import saves them in a single transaction and only when every row is valid,
+// while a best effort import saves them in batches of importBatchSize so a
+// failing batch does not undo the previous ones.
+func (s *beerService) ImportBeers(rows []entities.BeerImportRow, mode string) (*entities.BeerImportReport, *errors.RestError) {
+	mode, err := entities.ValidateImportMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.NewBadRequestError("import must have at least one row")
+	}
+
+	if len(rows) > maxImportRows {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("at most %d beers can be imported at once", maxImportRows))
+	}
+
+	report := &entities.BeerImportReport{
+		Mode:  mode,
+		Total: len(rows),
+		Rows:  make([]entities.BeerImportResult, len(rows)),
+	}
+	validRows := make([]int, 0, len(rows))
+	for i := range rows {
+		report.Rows[i].Row = rows[i].Row
+		if rows[i].Err == nil {
+			rows[i].Err = rows[i].Beer.Validate()
+		}
+
+		if rows[i].Err != nil {
+			report.Rows[i].Error = rows[i].Err
+			continue
+		}
+
+		validRows = append(validRows, i)
+	}
+
+	atomic := mode == entities.AllOrNothingImport
+	if atomic && len(validRows) < len(rows) {
+		return summarizeImport(report), nil
+	}
+
+	batchSize := importBatchSize
+	if atomic {
+		batchSize = len(validRows)
+	}
+
+	for start := 0; start < len(validRows); start += batchSize {
+		end := start + batchSize
+		if end > len(validRows) {
+			end = len(validRows)
+		}
+
+		batch := validRows[start:end]
+		beers := make([]entities.Beer, 0, len(batch))
+		for _, i := range batch {
+			beers = append(beers, rows[i].Beer)
+		}
+
+		results, err := s.beerRepository.SaveBatch(beers, atomic)
+		if err != nil {
+			if atomic {
+				return nil, err
+			}
+
+			for _, i := range batch {
+				report.Rows[i].Error = err
+			}
+			continue
+		}
+
+		for j, i := range batch {
+			report.Rows[i].Id = results[j].Id
+			report.Rows[i].Error = results[j].Err
+		}
+	}
+
+	return summarizeImport(report), nil
+}
+
+func summarizeImport(report *entities.BeerImportReport) *entities.BeerImportReport {
+	for _, row := range report.Rows {
+		if row.Error != nil {
+			report.Failed++
+		} else if row.Id != 0 {
+			report.Imported++
+		}
+	}
+
+	return report
+}
+
+func (s *beerService) UpdateBeer(beerID int64, beer entities.Beer) (*entities.Beer, *errors.RestError) {
+	if beer.Id != 0 && beer.Id != beerID {
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("body Id %d does not match beer id %d", beer.Id, beerID))
+	}
+
+	beer.Id = beerID
+	if err := beer.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.beerRepository.Update(beer); err != nil {
+		return nil, err
+	}
+
+	return &beer, nil
+}
+
+func (s *beerService) PatchBeer(beerID int64, patch []byte) (*entities.Beer, *errors.RestError) {
+	beer, err := s.GetBeerByID(beerID)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedBeer, err := applyMergePatch(*beer, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if patchedBeer.Id != beerID {
+		return nil, errors.NewBadRequestError("Id can not be modified")
+	}
+
+	if err := patchedBeer.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.beerRepository.Update(*patchedBeer); err != nil {
+		return nil, err
+	}
+
+	return patchedBeer, nil
+}
+
+func (s *beerService) DeleteBeer(beerID int64) *errors.RestError {
+	if err := s.beerRepository.Delete(beerID); err != nil {
 		return err
 	}
 
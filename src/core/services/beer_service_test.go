@@ -2,49 +2,170 @@ package services_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
 	"github.com/dleonsal/beers-api/src/core/services"
 	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+func Test_ListBeers_WhenQueryIsInvalid_ThenReturnError(t *testing.T) {
+	query := entities.NewBeerQuery()
+	query.Limit = entities.MaxBeerQueryLimit + 1
+	expectedError := errors.NewBadRequestError(
+		fmt.Sprintf("invalid limit: %d, it must be between 1 and %d", query.Limit, entities.MaxBeerQueryLimit))
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	beers, total, err := beerService.ListBeers(query)
+
+	assert.Nil(t, beers)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ListBeers_WhenCountRepositoryFail_ThenReturnError(t *testing.T) {
+	query := entities.NewBeerQuery()
+	expectedError := errors.NewInternalServerError("some error")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Count", query).Return(int64(0), expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	beers, total, err := beerService.ListBeers(query)
+
+	assert.Nil(t, beers)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
 func Test_ListBeers_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	query := entities.NewBeerQuery()
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerRepository := new(services.MockBeerRepository)
-	mockBeerRepository.On("List").Return(nil, expectedError)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	mockBeerRepository.On("Count", query).Return(int64(1), nil)
+	mockBeerRepository.On("List", query).Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
-	beers, err := beerService.ListBeers()
+	beers, total, err := beerService.ListBeers(query)
 
 	assert.Nil(t, beers)
+	assert.Equal(t, int64(0), total)
 	assert.Equal(t, expectedError, err)
 	mockBeerRepository.AssertExpectations(t)
 
 }
 
 func Test_ListBeers_WhenProcessIsExecutedSuccessfully_ThenReturnBeersList(t *testing.T) {
+	query := entities.NewBeerQuery()
 	expectedBeer := givenBeer()
 	expectedBeers := []entities.Beer{*expectedBeer}
 	mockBeerRepository := new(services.MockBeerRepository)
-	mockBeerRepository.On("List").Return(expectedBeers, nil)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	mockBeerRepository.On("Count", query).Return(int64(1), nil)
+	mockBeerRepository.On("List", query).Return(expectedBeers, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
-	beers, err := beerService.ListBeers()
+	beers, total, err := beerService.ListBeers(query)
 
 	assert.Equal(t, expectedBeers, beers)
+	assert.Equal(t, int64(1), total)
 	assert.Nil(t, err)
 	mockBeerRepository.AssertExpectations(t)
 
 }
 
+func Test_ExportBeers_WhenQueryFiltersAreInvalid_ThenReturnError(t *testing.T) {
+	query := entities.NewBeerQuery()
+	query.Currency = "XXX1"
+	expectedError := errors.NewBadRequestError("invalid currency: XXX1, it must be an ISO 4217 code")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	err := beerService.ExportBeers(query, "", nil)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ExportBeers_WhenNoTargetCurrency_ThenWriteBeersAsTheyAreStreamed(t *testing.T) {
+	query := entities.NewBeerQuery()
+	beer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Stream", query, mock.Anything).Return(
+		func(_ entities.BeerQuery, fn func(beer entities.Beer) *errors.RestError) *errors.RestError {
+			return fn(*beer)
+		})
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	var exports []entities.BeerExport
+	err := beerService.ExportBeers(query, "", func(export entities.BeerExport) *errors.RestError {
+		exports = append(exports, export)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerExport{{Beer: *beer}}, exports)
+	mockBeerRepository.AssertNotCalled(t, "ListCurrencies", mock.Anything)
+}
+
+func Test_ExportBeers_WhenExchangeRateFail_ThenReturnErrorBeforeStreaming(t *testing.T) {
+	query := entities.NewBeerQuery()
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("ListCurrencies", query).Return([]string{"COP"}, nil)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", "COP", "USD").Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, mockCurrencyConverterClient)
+
+	err := beerService.ExportBeers(query, "usd", nil)
+
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything)
+}
+
+func Test_ExportBeers_WhenTargetCurrency_ThenConvertEveryPriceWithOneRatePerCurrency(t *testing.T) {
+	query := entities.NewBeerQuery()
+	beer := givenBeer()
+	dollarBeer := entities.Beer{Id: 2, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: decimal.RequireFromString("1.5"), Currency: "USD"}
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("ListCurrencies", query).Return([]string{"COP", "USD"}, nil)
+	mockBeerRepository.On("Stream", query, mock.Anything).Return(
+		func(_ entities.BeerQuery, fn func(beer entities.Beer) *errors.RestError) *errors.RestError {
+			for _, beer := range []entities.Beer{*beer, dollarBeer, *beer} {
+				if err := fn(beer); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", "COP", "USD").
+		Return(givenExchangeRate("COP", "USD", "0.00024"), nil).Once()
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, mockCurrencyConverterClient)
+
+	var prices []money.Money
+	err := beerService.ExportBeers(query, "USD", func(export entities.BeerExport) *errors.RestError {
+		prices = append(prices, *export.ConvertedPrice)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, prices, 3)
+	assert.True(t, money.New(decimal.RequireFromString("0.6"), "USD").Equal(prices[0]))
+	assert.True(t, money.New(decimal.RequireFromString("1.5"), "USD").Equal(prices[1]))
+	assert.True(t, money.New(decimal.RequireFromString("0.6"), "USD").Equal(prices[2]))
+	mockCurrencyConverterClient.AssertExpectations(t)
+}
+
 func Test_GetBeerByID_WhenRepositoryFail_ThenReturnError(t *testing.T) {
 	id := int64(1)
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(nil, expectedError)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
 	beer, err := beerService.GetBeerByID(id)
 
@@ -59,7 +180,7 @@ func Test_GetBeerByID_WhenProcessIsExecutedSuccessfully_ThenReturnBeer(t *testin
 	expectedBeer := givenBeer()
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
 	beer, err := beerService.GetBeerByID(id)
 
@@ -73,13 +194,25 @@ func Test_GetBoxPrice_WhenNewCurrencyIsInvalid_ThenReturnError(t *testing.T) {
 	id := int64(1)
 	newCurrency := ""
 	quantity := uint64(10)
-	expectTotalPrice := 0.0
 	expectedError := errors.NewBadRequestError("currency must not be empty")
-	beerService := services.NewBeerService(nil, nil)
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectTotalPrice, totalPrice)
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenNewCurrencyIsNotISO4217_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	newCurrency := "XXX1"
+	quantity := uint64(10)
+	expectedError := errors.NewBadRequestError("invalid currency: XXX1, it must be an ISO 4217 code")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
+
+	assert.Nil(t, quote)
 	assert.Equal(t, expectedError, err)
 }
 
@@ -87,15 +220,14 @@ func Test_GetBoxPrice_WhenGetBeerRepositoryFail_ThenReturnError(t *testing.T) {
 	id := int64(1)
 	newCurrency := "USD"
 	quantity := uint64(10)
-	expectedTotalPrice := 0.0
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(nil, expectedError)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
+	assert.Nil(t, quote)
 	assert.Equal(t, expectedError, err)
 	mockBeerRepository.AssertExpectations(t)
 }
@@ -105,14 +237,35 @@ func Test_GetBoxPrice_WhenNewCurrencyIsEqualToCurrentCurrency_ThenReturnTotalPri
 	newCurrency := "COP"
 	quantity := uint64(10)
 	expectedBeer := givenBeer()
-	expectedTotalPrice := 25000.0
+	expectedTotalPrice := money.New(decimal.NewFromInt(25000), newCurrency)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
+
+	assert.True(t, expectedTotalPrice.Equal(quote.TotalPrice))
+	assert.True(t, decimal.NewFromInt(1).Equal(quote.ExchangeRate))
+	assert.Empty(t, quote.RateProvider)
+	assert.True(t, quote.RateTimestamp.IsZero())
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenNewCurrencyIsLowerCase_ThenNormalizeItBeforeComparing(t *testing.T) {
+	id := int64(1)
+	quantity := uint64(2)
+	expectedBeer := givenBeer()
+	expectedTotalPrice := money.New(decimal.NewFromInt(5000), "COP")
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, nil)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, "cop", quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
+	assert.True(t, expectedTotalPrice.Equal(quote.TotalPrice))
 	assert.Nil(t, err)
 	mockBeerRepository.AssertExpectations(t)
 }
@@ -122,18 +275,18 @@ func Test_GetBoxPrice_WhenCurrencyConverterClientFail_ThenReturnError(t *testing
 	newCurrency := "USD"
 	quantity := uint64(10)
 	expectedBeer := givenBeer()
-	expectedTotalPrice := 0.0
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
 	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
-	mockCurrencyConverterClient.On("ConvertValueToNewCurrency", expectedBeer.Currency, newCurrency, expectedBeer.Price).
-		Return(expectedTotalPrice, expectedError)
-	beerService := services.NewBeerService(mockBeerRepository, mockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(nil, expectedError)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
+	assert.Nil(t, quote)
 	assert.Equal(t, expectedError, err)
 	mockBeerRepository.AssertExpectations(t)
 	mockCurrencyConverterClient.AssertExpectations(t)
@@ -144,52 +297,448 @@ func Test_GetBoxPrice_WhenProcessIsExecutedSuccessfully_ThenReturnTotalPrice(t *
 	newCurrency := "USD"
 	quantity := uint64(10)
 	expectedBeer := givenBeer()
-	expectedTotalPrice := 6.0
+	expectedTotalPrice := money.New(decimal.RequireFromString("6"), newCurrency)
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
 	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
-	mockCurrencyConverterClient.On("ConvertValueToNewCurrency", expectedBeer.Currency, newCurrency, expectedBeer.Price).
-		Return(0.6, nil)
-	beerService := services.NewBeerService(mockBeerRepository, mockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
+	assert.Equal(t, id, quote.BeerID)
+	assert.Equal(t, expectedBeer.Currency, quote.SourceCurrency)
+	assert.Equal(t, newCurrency, quote.TargetCurrency)
+	assert.True(t, decimal.RequireFromString("0.00024").Equal(quote.ExchangeRate))
+	assert.True(t, money.New(decimal.RequireFromString("0.60"), newCurrency).Equal(quote.UnitPrice))
+	assert.Equal(t, quantity, quote.Quantity)
+	assert.True(t, expectedTotalPrice.Equal(quote.TotalPrice))
+	assert.Equal(t, "rapidapi", quote.RateProvider)
+	assert.Equal(t, time.Date(2021, 11, 19, 10, 30, 0, 0, time.UTC), quote.RateTimestamp)
 	assert.Nil(t, err)
 	mockBeerRepository.AssertExpectations(t)
 	mockCurrencyConverterClient.AssertExpectations(t)
 }
 
+func Test_GetBoxPrice_WhenQuantityIsMaxUint64_ThenReturnPositiveTotalPrice(t *testing.T) {
+	id := int64(1)
+	newCurrency := "USD"
+	quantity := uint64(math.MaxUint64)
+	expectedBeer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, quantity, quote.Quantity)
+	assert.True(t, quote.Subtotal.Amount.IsPositive())
+	assert.True(t, quote.TotalPrice.Amount.IsPositive())
+}
+
 func Test_GetBoxPrice_WhenProcessIsExecutedSuccessfullyWithQuantityZero_ThenReturnTotalPrice(t *testing.T) {
 	id := int64(1)
 	newCurrency := "USD"
 	quantity := uint64(0)
 	expectedBeer := givenBeer()
-	expectedTotalPrice := 3.5999999999999996
+	expectedTotalPrice := money.New(decimal.RequireFromString("3.6"), newCurrency)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
+
+	assert.True(t, expectedTotalPrice.Equal(quote.TotalPrice))
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
+	mockCurrencyConverterClient.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenConvertedPriceHasMoreDecimalsThanCurrency_ThenReturnRoundedTotalPrice(t *testing.T) {
+	id := int64(1)
+	newCurrency := "USD"
+	quantity := uint64(6)
+	expectedBeer := givenBeer()
+	expectedTotalPrice := money.New(decimal.RequireFromString("35.70"), newCurrency)
 	mockBeerRepository := new(services.MockBeerRepository)
 	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
 	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
-	mockCurrencyConverterClient.On("ConvertValueToNewCurrency", expectedBeer.Currency, newCurrency, expectedBeer.Price).
-		Return(0.6, nil)
-	beerService := services.NewBeerService(mockBeerRepository, mockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.0023799996"), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
 
-	totalPrice, err := beerService.GetBoxPrice(id, newCurrency, quantity)
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
+	assert.True(t, expectedTotalPrice.Equal(quote.TotalPrice))
+	assert.Equal(t, "35.70", quote.TotalPrice.Amount.StringFixed(2))
 	assert.Nil(t, err)
 	mockBeerRepository.AssertExpectations(t)
 	mockCurrencyConverterClient.AssertExpectations(t)
 }
 
+func Test_GetBoxPrice_WhenPricingRuleRepositoryFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewInternalServerError("error trying to get pricing rules from database")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("ListByBeer", id).Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 12, entities.BoxPriceOptions{})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+	mockPricingRuleRepository.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenPricingRulesApply_ThenSubtractDiscountLinesFromSubtotal(t *testing.T) {
+	id := int64(1)
+	newCurrency := "USD"
+	quantity := uint64(24)
+	expectedBeer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id,
+		entities.PricingRule{Id: 1, Name: "tier 1", Type: entities.PercentagePricingRule, MinQuantity: 12, Percentage: decimal.NewFromInt(5)},
+		entities.PricingRule{Id: 2, Name: "tier 2", Type: entities.PercentagePricingRule, MinQuantity: 24, Percentage: decimal.NewFromInt(10)},
+	)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, quantity, entities.BoxPriceOptions{})
+
+	assert.Nil(t, err)
+	assert.True(t, money.New(decimal.RequireFromString("14.40"), newCurrency).Equal(quote.Subtotal))
+	assert.Len(t, quote.Discounts, 1)
+	assert.Equal(t, int64(2), quote.Discounts[0].RuleID)
+	assert.True(t, money.New(decimal.RequireFromString("1.44"), newCurrency).Equal(quote.Discounts[0].Amount))
+	assert.True(t, money.New(decimal.RequireFromString("12.96"), newCurrency).Equal(quote.TotalPrice))
+	mockPricingRuleRepository.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenCouponDoesNotExist_ThenReturnBadRequestError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewBadRequestError("invalid coupon: SUMMER")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "SUMMER").Return(nil, errors.NewNotFoundError("coupon not found"))
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Coupon: " summer"})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+	mockCouponRepository.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenCouponLookupFails_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewInternalServerError("error trying to get coupon from database")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "SUMMER").Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Coupon: "SUMMER"})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenCouponDoesNotApplyToBeer_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewBadRequestError("coupon SUMMER does not apply to beer 1")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "SUMMER").
+		Return(&entities.Coupon{Code: "SUMMER", Type: entities.PercentageCoupon, Percentage: decimal.NewFromInt(10), Country: "Peru"}, nil)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Coupon: "SUMMER"})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenPercentageCouponApplies_ThenTakeItAfterPricingRules(t *testing.T) {
+	id := int64(1)
+	quantity := uint64(12)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id,
+		entities.PricingRule{Id: 1, Name: "tier 1", Type: entities.PercentagePricingRule, MinQuantity: 12, Percentage: decimal.NewFromInt(10)},
+	)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "SUMMER").
+		Return(&entities.Coupon{Code: "SUMMER", Type: entities.PercentageCoupon, Percentage: decimal.NewFromInt(5)}, nil)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", quantity, entities.BoxPriceOptions{Coupon: "SUMMER"})
+
+	assert.Nil(t, err)
+	assert.Len(t, quote.Discounts, 2)
+	assert.Equal(t, "SUMMER", quote.Discounts[1].CouponCode)
+	assert.Equal(t, "coupon SUMMER: 5% off", quote.Discounts[1].Description)
+	assert.True(t, money.New(decimal.NewFromInt(1350), "COP").Equal(quote.Discounts[1].Amount))
+	assert.True(t, money.New(decimal.NewFromInt(25650), "COP").Equal(quote.TotalPrice))
+}
+
+func Test_GetBoxPrice_WhenFixedCouponIsInAnotherCurrency_ThenConvertDiscount(t *testing.T) {
+	id := int64(1)
+	newCurrency := "USD"
+	expectedBeer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "WELCOME").
+		Return(&entities.Coupon{Code: "WELCOME", Type: entities.FixedCoupon, Amount: decimal.NewFromInt(1), Currency: "EUR"}, nil)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	mockCurrencyConverterClient.On("GetExchangeRate", "EUR", newCurrency).
+		Return(givenExchangeRate("EUR", newCurrency, "1.13"), nil)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, mockCurrencyConverterClient)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, 10, entities.BoxPriceOptions{Coupon: "WELCOME"})
+
+	assert.Nil(t, err)
+	assert.Len(t, quote.Discounts, 1)
+	assert.Equal(t, "coupon WELCOME: 1.00 EUR off", quote.Discounts[0].Description)
+	assert.True(t, money.New(decimal.RequireFromString("1.13"), newCurrency).Equal(quote.Discounts[0].Amount))
+	assert.True(t, money.New(decimal.RequireFromString("4.87"), newCurrency).Equal(quote.TotalPrice))
+	mockCurrencyConverterClient.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenFixedCouponIsGreaterThanTotal_ThenTotalIsZero(t *testing.T) {
+	id := int64(1)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByCode", "FREE").
+		Return(&entities.Coupon{Code: "FREE", Type: entities.FixedCoupon, Amount: decimal.NewFromInt(100000), Currency: "COP"}, nil)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, mockCouponRepository, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Coupon: "FREE"})
+
+	assert.Nil(t, err)
+	assert.True(t, money.New(decimal.NewFromInt(15000), "COP").Equal(quote.Discounts[0].Amount))
+	assert.True(t, quote.TotalPrice.Amount.IsZero())
+}
+
+func Test_GetBoxPrice_WhenDestinationIsInvalid_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewBadRequestError("invalid destination: Colombia, it must be an ISO 3166-1 alpha-2 code")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	beerService := services.NewBeerService(mockBeerRepository, givenPricingRuleRepository(id), nil, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Destination: "Colombia"})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenDestinationHasTaxRules_ThenChargeExciseBeforeVAT(t *testing.T) {
+	id := int64(1)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockTaxRuleRepository := new(services.MockTaxRuleRepository)
+	mockTaxRuleRepository.On("ListByCountry", "CO").Return([]entities.TaxRule{
+		{Id: 1, Country: "CO", Category: entities.GeneralTaxCategory, Name: "IVA", Type: entities.PercentageTax, Percentage: decimal.NewFromInt(19)},
+		{Id: 2, Country: "CO", Category: entities.BeerTaxCategory, Name: "Excise", Type: entities.PerUnitTax, Amount: decimal.NewFromInt(100), Currency: "COP"},
+	}, nil)
+	beerService := services.NewBeerService(mockBeerRepository, givenPricingRuleRepository(id), nil, mockTaxRuleRepository, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Destination: " co"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "CO", quote.Destination)
+	assert.True(t, money.New(decimal.NewFromInt(15000), "COP").Equal(quote.NetPrice))
+	assert.Len(t, quote.Taxes, 2)
+	assert.Equal(t, "Excise: 100.00 COP per unit", quote.Taxes[0].Description)
+	assert.True(t, money.New(decimal.NewFromInt(600), "COP").Equal(quote.Taxes[0].Amount))
+	assert.Equal(t, "IVA: 19%", quote.Taxes[1].Description)
+	assert.True(t, money.New(decimal.NewFromInt(2964), "COP").Equal(quote.Taxes[1].Amount))
+	assert.True(t, money.New(decimal.NewFromInt(18564), "COP").Equal(quote.TotalPrice))
+}
+
+func Test_GetBoxPrice_WhenPerUnitTaxIsInAnotherCurrency_ThenConvertTax(t *testing.T) {
+	id := int64(1)
+	newCurrency := "USD"
+	expectedBeer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil)
+	mockTaxRuleRepository := new(services.MockTaxRuleRepository)
+	mockTaxRuleRepository.On("ListByCountry", "DE").Return([]entities.TaxRule{
+		{Id: 3, Country: "DE", Category: entities.BeerTaxCategory, Name: "Biersteuer", Type: entities.PerUnitTax, Amount: decimal.RequireFromString("0.05"), Currency: "EUR"},
+	}, nil)
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, newCurrency).
+		Return(givenExchangeRate(expectedBeer.Currency, newCurrency, "0.00024"), nil)
+	mockCurrencyConverterClient.On("GetExchangeRate", "EUR", newCurrency).
+		Return(givenExchangeRate("EUR", newCurrency, "1.13"), nil)
+	beerService := services.NewBeerService(mockBeerRepository, givenPricingRuleRepository(id), nil, mockTaxRuleRepository, nil, mockCurrencyConverterClient)
+
+	quote, err := beerService.GetBoxPrice(id, newCurrency, 10, entities.BoxPriceOptions{Destination: "DE"})
+
+	assert.Nil(t, err)
+	assert.True(t, money.New(decimal.RequireFromString("6.00"), newCurrency).Equal(quote.NetPrice))
+	assert.True(t, money.New(decimal.RequireFromString("0.57"), newCurrency).Equal(quote.Taxes[0].Amount))
+	assert.True(t, money.New(decimal.RequireFromString("6.57"), newCurrency).Equal(quote.TotalPrice))
+	mockCurrencyConverterClient.AssertExpectations(t)
+}
+
+func Test_GetBoxPrice_WhenQuantityAndPackAreGiven_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewBadRequestError("quantity and pack can not be used together")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 6, entities.BoxPriceOptions{Pack: 12})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenPackDoesNotExist_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewBadRequestError("pack of 7 is not available for beer 1")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPackRepository := new(services.MockPackRepository)
+	mockPackRepository.On("GetByBeerAndSize", id, uint64(7)).Return(nil, errors.NewNotFoundError("pack not found"))
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, mockPackRepository, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 0, entities.BoxPriceOptions{Pack: 7})
+
+	assert.Nil(t, quote)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrice_WhenPackIsGiven_ThenQuotePackSizeAndAddPackagingCost(t *testing.T) {
+	id := int64(1)
+	pack := &entities.Pack{Id: 2, BeerId: id, Name: "12-pack", Size: 12, Surcharge: decimal.NewFromInt(1500), Currency: "COP"}
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(givenBeer(), nil)
+	mockPackRepository := new(services.MockPackRepository)
+	mockPackRepository.On("GetByBeerAndSize", id, uint64(12)).Return(pack, nil)
+	mockPricingRuleRepository := givenPricingRuleRepository(id, entities.PricingRule{
+		Id: 7, Name: "volume", Type: entities.PercentagePricingRule, MinQuantity: 12, Percentage: decimal.NewFromInt(10),
+	})
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, mockPackRepository, nil)
+
+	quote, err := beerService.GetBoxPrice(id, "COP", 0, entities.BoxPriceOptions{Pack: 12})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(12), quote.Quantity)
+	assert.Equal(t, pack, quote.Pack)
+	assert.True(t, money.New(decimal.NewFromInt(30000), "COP").Equal(quote.Subtotal))
+	assert.True(t, money.New(decimal.NewFromInt(1500), "COP").Equal(quote.PackagingCost))
+	assert.True(t, money.New(decimal.NewFromInt(28500), "COP").Equal(quote.NetPrice))
+	assert.True(t, money.New(decimal.NewFromInt(28500), "COP").Equal(quote.TotalPrice))
+}
+
+func Test_GetBoxPrices_WhenCurrenciesAreEmpty_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("currencies must not be empty")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	results, err := beerService.GetBoxPrices(1, nil, 6, entities.BoxPriceOptions{})
+
+	assert.Nil(t, results)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrices_WhenTooManyCurrencies_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("at most 20 currencies can be requested")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	results, err := beerService.GetBoxPrices(1, make([]string, 21), 6, entities.BoxPriceOptions{})
+
+	assert.Nil(t, results)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetBoxPrices_WhenGetBeerRepositoryFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	results, err := beerService.GetBoxPrices(id, []string{"USD", "EUR"}, 6, entities.BoxPriceOptions{})
+
+	assert.Nil(t, results)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_GetBoxPrices_WhenOneConversionFail_ThenReturnErrorOnlyForThatCurrency(t *testing.T) {
+	id := int64(1)
+	quantity := uint64(10)
+	expectedBeer := givenBeer()
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(expectedBeer, nil).Once()
+	mockCurrencyConverterClient := new(services.MockCurrencyConverterClient)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, "USD").
+		Return(givenExchangeRate(expectedBeer.Currency, "USD", "0.00024"), nil)
+	mockCurrencyConverterClient.On("GetExchangeRate", expectedBeer.Currency, "EUR").
+		Return(nil, expectedError)
+	mockPricingRuleRepository := givenPricingRuleRepository(id)
+	beerService := services.NewBeerService(mockBeerRepository, mockPricingRuleRepository, nil, nil, nil, mockCurrencyConverterClient)
+
+	results, err := beerService.GetBoxPrices(id, []string{"usd", "EUR", "COP", "XXX1"}, quantity, entities.BoxPriceOptions{})
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	assert.Equal(t, "USD", results[0].Currency)
+	assert.Nil(t, results[0].Err)
+	assert.True(t, money.New(decimal.RequireFromString("6"), "USD").Equal(results[0].Quote.TotalPrice))
+	assert.Equal(t, "EUR", results[1].Currency)
+	assert.Nil(t, results[1].Quote)
+	assert.Equal(t, expectedError, results[1].Err)
+	assert.Equal(t, "COP", results[2].Currency)
+	assert.True(t, money.New(decimal.NewFromInt(25000), "COP").Equal(results[2].Quote.TotalPrice))
+	assert.Equal(t, "XXX1", results[3].Currency)
+	assert.Equal(t, errors.NewBadRequestError("invalid currency: XXX1, it must be an ISO 4217 code"), results[3].Err)
+	mockBeerRepository.AssertExpectations(t)
+	mockCurrencyConverterClient.AssertExpectations(t)
+}
+
 func Test_CreateBeer_WhenBeerValidateFail_ThenReturnError(t *testing.T) {
 	beer := entities.Beer{
-		Id: 0,
+		Id: -1,
 	}
 	expectedError := errors.NewBadRequestError(fmt.Sprintf("invalid Id: %d", beer.Id))
-	beerService := services.NewBeerService(nil, nil)
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
 
-	err := beerService.CreateBeer(beer)
+	createdBeer, err := beerService.CreateBeer(beer)
 
+	assert.Nil(t, createdBeer)
 	assert.Equal(t, expectedError, err)
 }
 
@@ -197,27 +746,276 @@ func Test_CreateBeer_WhenRepositoryFail_ThenReturnError(t *testing.T) {
 	beer := givenBeer()
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerRepository := new(services.MockBeerRepository)
-	mockBeerRepository.On("Save", *beer).Return(expectedError)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	mockBeerRepository.On("Save", *beer).Return(int64(0), expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	createdBeer, err := beerService.CreateBeer(*beer)
+
+	assert.Nil(t, createdBeer)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+
+}
+
+func Test_CreateBeer_WhenProcessIsExecutedSuccessfully_ThenReturnBeer(t *testing.T) {
+	beer := givenBeer()
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Save", *beer).Return(beer.Id, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	createdBeer, err := beerService.CreateBeer(*beer)
+
+	assert.Equal(t, beer, createdBeer)
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
+
+}
+
+func Test_CreateBeer_WhenBeerHasNoId_ThenReturnBeerWithGeneratedId(t *testing.T) {
+	beer := givenBeer()
+	beer.Id = 0
+	expectedBeer := *beer
+	expectedBeer.Id = 7
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Save", *beer).Return(int64(7), nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	createdBeer, err := beerService.CreateBeer(*beer)
+
+	assert.Equal(t, &expectedBeer, createdBeer)
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_ImportBeers_WhenModeIsInvalid_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid mode: some, it must be all_or_nothing or best_effort")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	report, err := beerService.ImportBeers([]entities.BeerImportRow{{Row: 1, Beer: *givenBeer()}}, "some")
+
+	assert.Nil(t, report)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ImportBeers_WhenAllOrNothingAndOneRowIsInvalid_ThenSaveNothing(t *testing.T) {
+	invalidBeer := *givenBeer()
+	invalidBeer.Name = ""
+	rows := []entities.BeerImportRow{
+		{Row: 1, Beer: *givenBeer()},
+		{Row: 2, Beer: invalidBeer},
+		{Row: 3, Err: errors.NewBadRequestError("invalid Price: abc")},
+	}
+	mockBeerRepository := new(services.MockBeerRepository)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	report, err := beerService.ImportBeers(rows, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &entities.BeerImportReport{
+		Mode:   entities.AllOrNothingImport,
+		Total:  3,
+		Failed: 2,
+		Rows: []entities.BeerImportResult{
+			{Row: 1},
+			{Row: 2, Error: errors.NewBadRequestError("invalid Name: ")},
+			{Row: 3, Error: errors.NewBadRequestError("invalid Price: abc")},
+		},
+	}, report)
+	mockBeerRepository.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+}
+
+func Test_ImportBeers_WhenAllOrNothingAndSaveBatchFail_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to save beers in database")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("SaveBatch", []entities.Beer{*givenBeer()}, true).Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	report, err := beerService.ImportBeers([]entities.BeerImportRow{{Row: 1, Beer: *givenBeer()}}, entities.AllOrNothingImport)
+
+	assert.Nil(t, report)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ImportBeers_WhenBestEffort_ThenSaveValidRowsInBatchesAndReportConflicts(t *testing.T) {
+	rows := make([]entities.BeerImportRow, 0, 102)
+	for i := 1; i <= 101; i++ {
+		beer := *givenBeer()
+		beer.Id = int64(i)
+		rows = append(rows, entities.BeerImportRow{Row: i, Beer: beer})
+	}
+	rows = append(rows, entities.BeerImportRow{Row: 102, Err: errors.NewBadRequestError("row has 2 fields, expected 6")})
+	firstBatch := make([]entities.BeerSaveResult, 100)
+	for i := range firstBatch {
+		firstBatch[i].Id = int64(i + 1)
+	}
+	conflictError := errors.NewConflictError("beer id 1 already exists")
+	firstBatch[0] = entities.BeerSaveResult{Err: conflictError}
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("SaveBatch", mock.MatchedBy(func(beers []entities.Beer) bool { return len(beers) == 100 }), false).
+		Return(firstBatch, nil)
+	mockBeerRepository.On("SaveBatch", mock.MatchedBy(func(beers []entities.Beer) bool { return len(beers) == 1 }), false).
+		Return([]entities.BeerSaveResult{{Id: 101}}, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	report, err := beerService.ImportBeers(rows, entities.BestEffortImport)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 102, report.Total)
+	assert.Equal(t, 100, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, conflictError, report.Rows[0].Error)
+	assert.Equal(t, int64(101), report.Rows[100].Id)
+	mockBeerRepository.AssertNumberOfCalls(t, "SaveBatch", 2)
+}
+
+func Test_UpdateBeer_WhenBodyIdDoesNotMatchBeerID_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	expectedError := errors.NewBadRequestError("body Id 1 does not match beer id 2")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	updatedBeer, err := beerService.UpdateBeer(2, *beer)
+
+	assert.Nil(t, updatedBeer)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_UpdateBeer_WhenBeerValidateFail_ThenReturnError(t *testing.T) {
+	beer := entities.Beer{
+		Name: "",
+	}
+	expectedError := errors.NewBadRequestError("invalid Name: ")
+	beerService := services.NewBeerService(nil, nil, nil, nil, nil, nil)
+
+	updatedBeer, err := beerService.UpdateBeer(1, beer)
+
+	assert.Nil(t, updatedBeer)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_UpdateBeer_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Update", *beer).Return(expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	updatedBeer, err := beerService.UpdateBeer(beer.Id, *beer)
+
+	assert.Nil(t, updatedBeer)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_UpdateBeer_WhenProcessIsExecutedSuccessfully_ThenReturnBeer(t *testing.T) {
+	expectedBeer := givenBeer()
+	beer := *expectedBeer
+	beer.Id = 0
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Update", *expectedBeer).Return(nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	updatedBeer, err := beerService.UpdateBeer(expectedBeer.Id, beer)
+
+	assert.Equal(t, expectedBeer, updatedBeer)
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_PatchBeer_WhenGetBeerRepositoryFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", id).Return(nil, expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	patchedBeer, err := beerService.PatchBeer(id, []byte(`{"Price": 3000}`))
+
+	assert.Nil(t, patchedBeer)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_PatchBeer_WhenPatchIsNotAnObject_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	expectedError := errors.NewBadRequestError("patch must be a json object")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beer.Id).Return(beer, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	patchedBeer, err := beerService.PatchBeer(beer.Id, []byte(`[1, 2]`))
+
+	assert.Nil(t, patchedBeer)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_PatchBeer_WhenPatchModifiesId_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	expectedError := errors.NewBadRequestError("Id can not be modified")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beer.Id).Return(beer, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
-	err := beerService.CreateBeer(*beer)
+	patchedBeer, err := beerService.PatchBeer(beer.Id, []byte(`{"Id": 5}`))
 
+	assert.Nil(t, patchedBeer)
 	assert.Equal(t, expectedError, err)
 	mockBeerRepository.AssertExpectations(t)
+}
 
+func Test_PatchBeer_WhenPatchRemovesRequiredField_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	expectedError := errors.NewBadRequestError("invalid Brewery: ")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beer.Id).Return(beer, nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	patchedBeer, err := beerService.PatchBeer(beer.Id, []byte(`{"Brewery": null}`))
+
+	assert.Nil(t, patchedBeer)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
 }
 
-func Test_CreateBeer_WhenProcessIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+func Test_PatchBeer_WhenProcessIsExecutedSuccessfully_ThenReturnPatchedBeer(t *testing.T) {
 	beer := givenBeer()
+	expectedBeer := *beer
+	expectedBeer.Price = decimal.RequireFromString("3000")
 	mockBeerRepository := new(services.MockBeerRepository)
-	mockBeerRepository.On("Save", *beer).Return(nil)
-	beerService := services.NewBeerService(mockBeerRepository, nil)
+	mockBeerRepository.On("GetByID", beer.Id).Return(beer, nil)
+	mockBeerRepository.On("Update", expectedBeer).Return(nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
 
-	err := beerService.CreateBeer(*beer)
+	patchedBeer, err := beerService.PatchBeer(beer.Id, []byte(`{"Price": 3000}`))
 
+	assert.Equal(t, &expectedBeer, patchedBeer)
 	assert.Nil(t, err)
 	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_DeleteBeer_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Delete", id).Return(expectedError)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	err := beerService.DeleteBeer(id)
+
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_DeleteBeer_WhenProcessIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+	id := int64(1)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("Delete", id).Return(nil)
+	beerService := services.NewBeerService(mockBeerRepository, nil, nil, nil, nil, nil)
+
+	err := beerService.DeleteBeer(id)
 
+	assert.Nil(t, err)
+	mockBeerRepository.AssertExpectations(t)
 }
 
 func givenBeer() *entities.Beer {
@@ -226,7 +1024,24 @@ func givenBeer() *entities.Beer {
 		Name:     "Pilsen",
 		Brewery:  "Bavaria",
 		Country:  "Colombia",
-		Price:    2500,
+		Price:    decimal.NewFromInt(2500),
 		Currency: "COP",
 	}
 }
+
+func givenPricingRuleRepository(beerID int64, rules ...entities.PricingRule) *services.MockPricingRuleRepository {
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("ListByBeer", beerID).Return(append([]entities.PricingRule{}, rules...), nil)
+
+	return mockPricingRuleRepository
+}
+
+func givenExchangeRate(oldCurrency, newCurrency, rate string) *money.ExchangeRate {
+	return &money.ExchangeRate{
+		From:      oldCurrency,
+		To:        newCurrency,
+		Rate:      decimal.RequireFromString(rate),
+		Provider:  "rapidapi",
+		Timestamp: time.Date(2021, 11, 19, 10, 30, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type couponService struct {
+	couponRepository CouponRepository
+	beerRepository   BeerRepository
+}
+
+func NewCouponService(couponRepository CouponRepository, beerRepository BeerRepository) *couponService {
+	return &couponService{
+		couponRepository: couponRepository,
+		beerRepository:   beerRepository,
+	}
+}
+
+func (s *couponService) ListCoupons() ([]entities.Coupon, *errors.RestError) {
+	return s.couponRepository.List()
+}
+
+func (s *couponService) GetCouponByID(couponID int64) (*entities.Coupon, *errors.RestError) {
+	return s.couponRepository.GetByID(couponID)
+}
+
+// CreateCoupon saves a new coupon. Uses always starts at zero.
+func (s *couponService) CreateCoupon(coupon entities.Coupon) (*entities.Coupon, *errors.RestError) {
+	if err := s.validate(&coupon); err != nil {
+		return nil, err
+	}
+
+	coupon.Uses = 0
+	couponID, err := s.couponRepository.Save(coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	coupon.Id = couponID
+	return &coupon, nil
+}
+
+// UpdateCoupon replaces the coupon, keeping the uses it has already counted.
+func (s *couponService) UpdateCoupon(couponID int64, coupon entities.Coupon) (*entities.Coupon, *errors.RestError) {
+	if coupon.Id != 0 && coupon.Id != couponID {
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("body Id %d does not match coupon id %d", coupon.Id, couponID))
+	}
+
+	coupon.Id = couponID
+	if err := s.validate(&coupon); err != nil {
+		return nil, err
+	}
+
+	current, err := s.couponRepository.GetByID(couponID)
+	if err != nil {
+		return nil, err
+	}
+	coupon.Uses = current.Uses
+
+	if err := s.couponRepository.Update(coupon); err != nil {
+		return nil, err
+	}
+
+	return &coupon, nil
+}
+
+func (s *couponService) DeleteCoupon(couponID int64) *errors.RestError {
+	return s.couponRepository.Delete(couponID)
+}
+
+// RedeemCoupon counts one use of the coupon when it is within its validity
+// window and has uses left.
+func (s *couponService) RedeemCoupon(couponID int64) (*entities.Coupon, *errors.RestError) {
+	coupon, err := s.couponRepository.GetByID(couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := coupon.CheckRedeemable(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.couponRepository.Redeem(couponID); err != nil {
+		return nil, err
+	}
+
+	coupon.Uses++
+	return coupon, nil
+}
+
+// validate checks the coupon fields and that the beer it targets exists.
+func (s *couponService) validate(coupon *entities.Coupon) *errors.RestError {
+	if err := coupon.Validate(); err != nil {
+		return err
+	}
+
+	if coupon.BeerId == nil {
+		return nil
+	}
+
+	if _, err := s.beerRepository.GetByID(*coupon.BeerId); err != nil {
+		if err.Status == http.StatusNotFound {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid BeerId: %d, beer not found", *coupon.BeerId))
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateCoupon_WhenCouponIsInvalid_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid Amount: 0")
+	couponService := services.NewCouponService(nil, nil)
+
+	coupon, err := couponService.CreateCoupon(entities.Coupon{Code: "WELCOME", Type: entities.FixedCoupon, Currency: "USD"})
+
+	assert.Nil(t, coupon)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreateCoupon_WhenBeerDoesNotExist_ThenReturnError(t *testing.T) {
+	beerID := int64(9)
+	expectedError := errors.NewBadRequestError("invalid BeerId: 9, beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beerID).Return(nil, errors.NewNotFoundError("beer not found"))
+	couponService := services.NewCouponService(nil, mockBeerRepository)
+	coupon := givenCoupon()
+	coupon.BeerId = &beerID
+
+	createdCoupon, err := couponService.CreateCoupon(coupon)
+
+	assert.Nil(t, createdCoupon)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreateCoupon_WhenProcessIsExecutedSuccessfully_ThenResetUses(t *testing.T) {
+	coupon := givenCoupon()
+	coupon.Uses = 10
+	expectedCoupon := givenCoupon()
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("Save", expectedCoupon).Return(int64(2), nil)
+	couponService := services.NewCouponService(mockCouponRepository, nil)
+
+	createdCoupon, err := couponService.CreateCoupon(coupon)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), createdCoupon.Id)
+	assert.Equal(t, uint64(0), createdCoupon.Uses)
+	mockCouponRepository.AssertExpectations(t)
+}
+
+func Test_UpdateCoupon_WhenProcessIsExecutedSuccessfully_ThenKeepUses(t *testing.T) {
+	current := givenCoupon()
+	current.Id = 2
+	current.Uses = 4
+	expectedCoupon := current
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByID", int64(2)).Return(&current, nil)
+	mockCouponRepository.On("Update", expectedCoupon).Return(nil)
+	couponService := services.NewCouponService(mockCouponRepository, nil)
+
+	coupon, err := couponService.UpdateCoupon(2, givenCoupon())
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedCoupon, *coupon)
+	mockCouponRepository.AssertExpectations(t)
+}
+
+func Test_RedeemCoupon_WhenCouponHasExpired_ThenReturnError(t *testing.T) {
+	coupon := givenCoupon()
+	validUntil := time.Now().Add(-time.Hour)
+	coupon.ValidUntil = &validUntil
+	expectedError := errors.NewBadRequestError("coupon WELCOME has expired")
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByID", int64(2)).Return(&coupon, nil)
+	couponService := services.NewCouponService(mockCouponRepository, nil)
+
+	redeemedCoupon, err := couponService.RedeemCoupon(2)
+
+	assert.Nil(t, redeemedCoupon)
+	assert.Equal(t, expectedError, err)
+	mockCouponRepository.AssertNotCalled(t, "Redeem", int64(2))
+}
+
+func Test_RedeemCoupon_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	coupon := givenCoupon()
+	expectedError := errors.NewConflictError("coupon 2 has reached its usage limit")
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByID", int64(2)).Return(&coupon, nil)
+	mockCouponRepository.On("Redeem", int64(2)).Return(expectedError)
+	couponService := services.NewCouponService(mockCouponRepository, nil)
+
+	redeemedCoupon, err := couponService.RedeemCoupon(2)
+
+	assert.Nil(t, redeemedCoupon)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_RedeemCoupon_WhenProcessIsExecutedSuccessfully_ThenReturnCouponWithOneMoreUse(t *testing.T) {
+	coupon := givenCoupon()
+	coupon.Uses = 1
+	mockCouponRepository := new(services.MockCouponRepository)
+	mockCouponRepository.On("GetByID", int64(2)).Return(&coupon, nil)
+	mockCouponRepository.On("Redeem", int64(2)).Return(nil)
+	couponService := services.NewCouponService(mockCouponRepository, nil)
+
+	redeemedCoupon, err := couponService.RedeemCoupon(2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), redeemedCoupon.Uses)
+}
+
+func givenCoupon() entities.Coupon {
+	return entities.Coupon{
+		Code:     "WELCOME",
+		Type:     entities.FixedCoupon,
+		Amount:   decimal.NewFromInt(5),
+		Currency: "USD",
+		MaxUses:  10,
+	}
+}
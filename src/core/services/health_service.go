@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/contracts"
+)
+
+// HealthCheck probes a dependency of the application. The application is not
+// ready while a critical dependency is down.
+type HealthCheck struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+type healthService struct {
+	checks  []HealthCheck
+	timeout time.Duration
+}
+
+func NewHealthService(timeout time.Duration, checks ...HealthCheck) *healthService {
+	return &healthService{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Readiness runs the checks concurrently, failing the ones that take longer
+// than the timeout even when they ignore the context.
+func (s *healthService) Readiness(ctx context.Context) contracts.HealthResponse {
+	dependencies := make([]contracts.DependencyHealthResponse, len(s.checks))
+	done := make(chan struct{}, len(s.checks))
+
+	for i, check := range s.checks {
+		go func(i int, check HealthCheck) {
+			dependencies[i] = s.runCheck(ctx, check)
+			done <- struct{}{}
+		}(i, check)
+	}
+
+	for range s.checks {
+		<-done
+	}
+
+	response := contracts.HealthResponse{
+		Status:       contracts.HealthStatusUp,
+		Dependencies: dependencies,
+	}
+
+	for _, dependency := range dependencies {
+		if dependency.Status == contracts.HealthStatusUp {
+			continue
+		}
+
+		if dependency.Critical {
+			response.Status = contracts.HealthStatusDown
+			break
+		}
+
+		response.Status = contracts.HealthStatusDegraded
+	}
+
+	return response
+}
+
+func (s *healthService) runCheck(ctx context.Context, check HealthCheck) contracts.DependencyHealthResponse {
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		result <- check.Check(checkCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-checkCtx.Done():
+		err = checkCtx.Err()
+	}
+
+	dependency := contracts.DependencyHealthResponse{
+		Name:                check.Name,
+		Status:              contracts.HealthStatusUp,
+		Critical:            check.Critical,
+		LatencyMilliseconds: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if err != nil {
+		dependency.Status = contracts.HealthStatusDown
+		dependency.Error = err.Error()
+	}
+
+	return dependency
+}
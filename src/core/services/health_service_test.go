@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	genericerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/contracts"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Readiness_WhenEveryCheckPasses_ThenReturnUp(t *testing.T) {
+	healthService := services.NewHealthService(time.Second,
+		givenHealthCheck("mysql", true, nil),
+		givenHealthCheck("currency-converter", false, nil))
+
+	health := healthService.Readiness(context.Background())
+
+	assert.Equal(t, contracts.HealthStatusUp, health.Status)
+	assert.Len(t, health.Dependencies, 2)
+	assert.Equal(t, "mysql", health.Dependencies[0].Name)
+	assert.Equal(t, contracts.HealthStatusUp, health.Dependencies[0].Status)
+	assert.True(t, health.Dependencies[0].Critical)
+	assert.Equal(t, "currency-converter", health.Dependencies[1].Name)
+	assert.GreaterOrEqual(t, health.Dependencies[1].LatencyMilliseconds, float64(0))
+}
+
+func Test_Readiness_WhenNonCriticalCheckFails_ThenReturnDegraded(t *testing.T) {
+	healthService := services.NewHealthService(time.Second,
+		givenHealthCheck("mysql", true, nil),
+		givenHealthCheck("currency-converter", false, genericerrors.New("rates not available")))
+
+	health := healthService.Readiness(context.Background())
+
+	assert.Equal(t, contracts.HealthStatusDegraded, health.Status)
+	assert.Equal(t, contracts.HealthStatusDown, health.Dependencies[1].Status)
+	assert.Equal(t, "rates not available", health.Dependencies[1].Error)
+}
+
+func Test_Readiness_WhenCriticalCheckFails_ThenReturnDown(t *testing.T) {
+	healthService := services.NewHealthService(time.Second,
+		givenHealthCheck("currency-converter", false, genericerrors.New("rates not available")),
+		givenHealthCheck("mysql", true, genericerrors.New("connection refused")))
+
+	health := healthService.Readiness(context.Background())
+
+	assert.Equal(t, contracts.HealthStatusDown, health.Status)
+	assert.Equal(t, "connection refused", health.Dependencies[1].Error)
+}
+
+func Test_Readiness_WhenCheckIgnoresTimeout_ThenReturnDownAfterTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	healthService := services.NewHealthService(20*time.Millisecond, services.HealthCheck{
+		Name:     "mysql",
+		Critical: true,
+		Check: func(context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	start := time.Now()
+	health := healthService.Readiness(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, contracts.HealthStatusDown, health.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), health.Dependencies[0].Error)
+}
+
+func Test_Readiness_WhenThereAreNoChecks_ThenReturnUp(t *testing.T) {
+	healthService := services.NewHealthService(time.Second)
+
+	health := healthService.Readiness(context.Background())
+
+	assert.Equal(t, contracts.HealthStatusUp, health.Status)
+	assert.Empty(t, health.Dependencies)
+}
+
+func givenHealthCheck(name string, critical bool, err error) services.HealthCheck {
+	return services.HealthCheck{
+		Name:     name,
+		Critical: critical,
+		Check: func(context.Context) error {
+			return err
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+)
+
+// applyMergePatch applies a JSON merge patch (RFC 7396) to the given beer and
+// returns the resulting beer. Members set to null are reset to their zero value.
+func applyMergePatch(beer entities.Beer, patch []byte) (*entities.Beer, *errors.RestError) {
+	var patchDocument map[string]interface{}
+	if err := json.Unmarshal(patch, &patchDocument); err != nil || patchDocument == nil {
+		return nil, errors.NewBadRequestError("patch must be a json object")
+	}
+
+	beerBytes, err := json.Marshal(beer)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to marshal beer: %s", err))
+		return nil, errors.NewInternalServerError("error trying to patch beer")
+	}
+
+	var beerDocument map[string]interface{}
+	if err := json.Unmarshal(beerBytes, &beerDocument); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to unmarshal beer: %s", err))
+		return nil, errors.NewInternalServerError("error trying to patch beer")
+	}
+
+	patchedBytes, err := json.Marshal(mergePatch(beerDocument, patchDocument))
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to marshal patched beer: %s", err))
+		return nil, errors.NewInternalServerError("error trying to patch beer")
+	}
+
+	var patchedBeer entities.Beer
+	if err := json.Unmarshal(patchedBytes, &patchedBeer); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to unmarshal patched beer: %s", err))
+		return nil, errors.NewBadRequestError("invalid patch values")
+	}
+
+	return &patchedBeer, nil
+}
+
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	for key, patchValue := range patch {
+		if patchValue == nil {
+			delete(target, key)
+			continue
+		}
+
+		patchObject, isObject := patchValue.(map[string]interface{})
+		if !isObject {
+			target[key] = patchValue
+			continue
+		}
+
+		targetObject, ok := target[key].(map[string]interface{})
+		if !ok {
+			targetObject = map[string]interface{}{}
+		}
+		target[key] = mergePatch(targetObject, patchObject)
+	}
+
+	return target
+}
@@ -14,6 +14,45 @@ type MockBeerRepository struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: query
+func (_m *MockBeerRepository) Count(query entities.BeerQuery) (int64, *errors.RestError) {
+	ret := _m.Called(query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery) int64); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(entities.BeerQuery) *errors.RestError); ok {
+		r1 = rf(query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: beerID
+func (_m *MockBeerRepository) Delete(beerID int64) *errors.RestError {
+	ret := _m.Called(beerID)
+
+	var r0 *errors.RestError
+	if rf, ok := ret.Get(0).(func(int64) *errors.RestError); ok {
+		r0 = rf(beerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errors.RestError)
+		}
+	}
+
+	return r0
+}
+
 // GetByID provides a mock function with given fields: beerID
 func (_m *MockBeerRepository) GetByID(beerID int64) (*entities.Beer, *errors.RestError) {
 	ret := _m.Called(beerID)
@@ -39,13 +78,13 @@ func (_m *MockBeerRepository) GetByID(beerID int64) (*entities.Beer, *errors.Res
 	return r0, r1
 }
 
-// List provides a mock function with given fields:
-func (_m *MockBeerRepository) List() ([]entities.Beer, *errors.RestError) {
-	ret := _m.Called()
+// List provides a mock function with given fields: query
+func (_m *MockBeerRepository) List(query entities.BeerQuery) ([]entities.Beer, *errors.RestError) {
+	ret := _m.Called(query)
 
 	var r0 []entities.Beer
-	if rf, ok := ret.Get(0).(func() []entities.Beer); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery) []entities.Beer); ok {
+		r0 = rf(query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entities.Beer)
@@ -53,8 +92,33 @@ func (_m *MockBeerRepository) List() ([]entities.Beer, *errors.RestError) {
 	}
 
 	var r1 *errors.RestError
-	if rf, ok := ret.Get(1).(func() *errors.RestError); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(entities.BeerQuery) *errors.RestError); ok {
+		r1 = rf(query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ListCurrencies provides a mock function with given fields: query
+func (_m *MockBeerRepository) ListCurrencies(query entities.BeerQuery) ([]string, *errors.RestError) {
+	ret := _m.Called(query)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery) []string); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(entities.BeerQuery) *errors.RestError); ok {
+		r1 = rf(query)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.RestError)
@@ -65,7 +129,71 @@ func (_m *MockBeerRepository) List() ([]entities.Beer, *errors.RestError) {
 }
 
 // Save provides a mock function with given fields: beer
-func (_m *MockBeerRepository) Save(beer entities.Beer) *errors.RestError {
+func (_m *MockBeerRepository) Save(beer entities.Beer) (int64, *errors.RestError) {
+	ret := _m.Called(beer)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(entities.Beer) int64); ok {
+		r0 = rf(beer)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(entities.Beer) *errors.RestError); ok {
+		r1 = rf(beer)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveBatch provides a mock function with given fields: beers, atomic
+func (_m *MockBeerRepository) SaveBatch(beers []entities.Beer, atomic bool) ([]entities.BeerSaveResult, *errors.RestError) {
+	ret := _m.Called(beers, atomic)
+
+	var r0 []entities.BeerSaveResult
+	if rf, ok := ret.Get(0).(func([]entities.Beer, bool) []entities.BeerSaveResult); ok {
+		r0 = rf(beers, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.BeerSaveResult)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func([]entities.Beer, bool) *errors.RestError); ok {
+		r1 = rf(beers, atomic)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// Stream provides a mock function with given fields: query, fn
+func (_m *MockBeerRepository) Stream(query entities.BeerQuery, fn func(entities.Beer) *errors.RestError) *errors.RestError {
+	ret := _m.Called(query, fn)
+
+	var r0 *errors.RestError
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery, func(entities.Beer) *errors.RestError) *errors.RestError); ok {
+		r0 = rf(query, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errors.RestError)
+		}
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: beer
+func (_m *MockBeerRepository) Update(beer entities.Beer) *errors.RestError {
 	ret := _m.Called(beer)
 
 	var r0 *errors.RestError
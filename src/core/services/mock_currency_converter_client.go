@@ -3,6 +3,7 @@
 package services
 
 import (
+	money "github.com/dleonsal/beers-api/src/core/domain/money"
 	errors "github.com/dleonsal/beers-api/src/errors"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -12,20 +13,22 @@ type MockCurrencyConverterClient struct {
 	mock.Mock
 }
 
-// ConvertValueToNewCurrency provides a mock function with given fields: oldCurrency, newCurrency, value
-func (_m *MockCurrencyConverterClient) ConvertValueToNewCurrency(oldCurrency string, newCurrency string, value float64) (float64, *errors.RestError) {
-	ret := _m.Called(oldCurrency, newCurrency, value)
+// GetExchangeRate provides a mock function with given fields: oldCurrency, newCurrency
+func (_m *MockCurrencyConverterClient) GetExchangeRate(oldCurrency string, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
+	ret := _m.Called(oldCurrency, newCurrency)
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func(string, string, float64) float64); ok {
-		r0 = rf(oldCurrency, newCurrency, value)
+	var r0 *money.ExchangeRate
+	if rf, ok := ret.Get(0).(func(string, string) *money.ExchangeRate); ok {
+		r0 = rf(oldCurrency, newCurrency)
 	} else {
-		r0 = ret.Get(0).(float64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*money.ExchangeRate)
+		}
 	}
 
 	var r1 *errors.RestError
-	if rf, ok := ret.Get(1).(func(string, string, float64) *errors.RestError); ok {
-		r1 = rf(oldCurrency, newCurrency, value)
+	if rf, ok := ret.Get(1).(func(string, string) *errors.RestError); ok {
+		r1 = rf(oldCurrency, newCurrency)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.RestError)
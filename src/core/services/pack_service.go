@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type packService struct {
+	packRepository PackRepository
+	beerRepository BeerRepository
+}
+
+func NewPackService(packRepository PackRepository, beerRepository BeerRepository) *packService {
+	return &packService{
+		packRepository: packRepository,
+		beerRepository: beerRepository,
+	}
+}
+
+func (s *packService) ListPacks() ([]entities.Pack, *errors.RestError) {
+	return s.packRepository.List()
+}
+
+func (s *packService) ListBeerPacks(beerID int64) ([]entities.Pack, *errors.RestError) {
+	if _, err := s.beerRepository.GetByID(beerID); err != nil {
+		return nil, err
+	}
+
+	return s.packRepository.ListByBeer(beerID)
+}
+
+func (s *packService) GetPackByID(packID int64) (*entities.Pack, *errors.RestError) {
+	return s.packRepository.GetByID(packID)
+}
+
+func (s *packService) CreatePack(pack entities.Pack) (*entities.Pack, *errors.RestError) {
+	if err := s.validate(&pack); err != nil {
+		return nil, err
+	}
+
+	packID, err := s.packRepository.Save(pack)
+	if err != nil {
+		return nil, err
+	}
+
+	pack.Id = packID
+	return &pack, nil
+}
+
+func (s *packService) UpdatePack(packID int64, pack entities.Pack) (*entities.Pack, *errors.RestError) {
+	if pack.Id != 0 && pack.Id != packID {
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("body Id %d does not match pack id %d", pack.Id, packID))
+	}
+
+	pack.Id = packID
+	if err := s.validate(&pack); err != nil {
+		return nil, err
+	}
+
+	if err := s.packRepository.Update(pack); err != nil {
+		return nil, err
+	}
+
+	return &pack, nil
+}
+
+func (s *packService) DeletePack(packID int64) *errors.RestError {
+	return s.packRepository.Delete(packID)
+}
+
+// validate checks the pack fields and that its beer exists.
+func (s *packService) validate(pack *entities.Pack) *errors.RestError {
+	if err := pack.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.beerRepository.GetByID(pack.BeerId); err != nil {
+		if err.Status == http.StatusNotFound {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid BeerId: %d, beer not found", pack.BeerId))
+		}
+
+		return err
+	}
+
+	return nil
+}
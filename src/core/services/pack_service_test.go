@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ListBeerPacks_WhenBeerDoesNotExist_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", int64(1)).Return(nil, expectedError)
+	packService := services.NewPackService(nil, mockBeerRepository)
+
+	packs, err := packService.ListBeerPacks(1)
+
+	assert.Nil(t, packs)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreatePack_WhenBeerDoesNotExist_ThenReturnBadRequestError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid BeerId: 1, beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", int64(1)).Return(nil, errors.NewNotFoundError("beer not found"))
+	packService := services.NewPackService(nil, mockBeerRepository)
+
+	pack, err := packService.CreatePack(givenPack())
+
+	assert.Nil(t, pack)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreatePack_WhenProcessIsExecutedSuccessfully_ThenReturnPackWithGeneratedId(t *testing.T) {
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", int64(1)).Return(givenBeer(), nil)
+	mockPackRepository := new(services.MockPackRepository)
+	mockPackRepository.On("Save", givenPack()).Return(int64(2), nil)
+	packService := services.NewPackService(mockPackRepository, mockBeerRepository)
+
+	pack, err := packService.CreatePack(givenPack())
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), pack.Id)
+}
+
+func Test_UpdatePack_WhenBodyIdDoesNotMatchPackID_ThenReturnError(t *testing.T) {
+	pack := givenPack()
+	pack.Id = 3
+	expectedError := errors.NewBadRequestError("body Id 3 does not match pack id 2")
+	packService := services.NewPackService(nil, nil)
+
+	updatedPack, err := packService.UpdatePack(2, pack)
+
+	assert.Nil(t, updatedPack)
+	assert.Equal(t, expectedError, err)
+}
+
+func givenPack() entities.Pack {
+	return entities.Pack{
+		BeerId:    1,
+		Name:      "12-pack",
+		Size:      12,
+		Surcharge: decimal.NewFromInt(1500),
+		Currency:  "COP",
+	}
+}
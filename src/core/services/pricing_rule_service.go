@@ -0,0 +1,87 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type pricingRuleService struct {
+	pricingRuleRepository PricingRuleRepository
+	beerRepository        BeerRepository
+}
+
+func NewPricingRuleService(pricingRuleRepository PricingRuleRepository, beerRepository BeerRepository) *pricingRuleService {
+	return &pricingRuleService{
+		pricingRuleRepository: pricingRuleRepository,
+		beerRepository:        beerRepository,
+	}
+}
+
+func (s *pricingRuleService) ListPricingRules() ([]entities.PricingRule, *errors.RestError) {
+	return s.pricingRuleRepository.List()
+}
+
+func (s *pricingRuleService) GetPricingRuleByID(ruleID int64) (*entities.PricingRule, *errors.RestError) {
+	return s.pricingRuleRepository.GetByID(ruleID)
+}
+
+func (s *pricingRuleService) CreatePricingRule(rule entities.PricingRule) (*entities.PricingRule, *errors.RestError) {
+	if err := s.validate(&rule); err != nil {
+		return nil, err
+	}
+
+	ruleID, err := s.pricingRuleRepository.Save(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Id = ruleID
+	return &rule, nil
+}
+
+func (s *pricingRuleService) UpdatePricingRule(ruleID int64, rule entities.PricingRule) (*entities.PricingRule, *errors.RestError) {
+	if rule.Id != 0 && rule.Id != ruleID {
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("body Id %d does not match pricing rule id %d", rule.Id, ruleID))
+	}
+
+	rule.Id = ruleID
+	if err := s.validate(&rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.pricingRuleRepository.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *pricingRuleService) DeletePricingRule(ruleID int64) *errors.RestError {
+	return s.pricingRuleRepository.Delete(ruleID)
+}
+
+// validate checks the rule fields and that the beer it targets exists.
+func (s *pricingRuleService) validate(rule *entities.PricingRule) *errors.RestError {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if rule.BeerId == nil {
+		return nil
+	}
+
+	if _, err := s.beerRepository.GetByID(*rule.BeerId); err != nil {
+		if err.Status == http.StatusNotFound {
+			return errors.NewBadRequestError(
+				fmt.Sprintf("invalid BeerId: %d, beer not found", *rule.BeerId))
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_ListPricingRules_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("some error")
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("List").Return(nil, expectedError)
+	pricingRuleService := services.NewPricingRuleService(mockPricingRuleRepository, nil)
+
+	rules, err := pricingRuleService.ListPricingRules()
+
+	assert.Nil(t, rules)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreatePricingRule_WhenRuleIsInvalid_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid BuyQuantity: 0")
+	pricingRuleService := services.NewPricingRuleService(nil, nil)
+
+	rule, err := pricingRuleService.CreatePricingRule(entities.PricingRule{Name: "promo", Type: entities.BuyNGetOnePricingRule})
+
+	assert.Nil(t, rule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreatePricingRule_WhenBeerDoesNotExist_ThenReturnError(t *testing.T) {
+	beerID := int64(9)
+	expectedError := errors.NewBadRequestError("invalid BeerId: 9, beer not found")
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beerID).Return(nil, errors.NewNotFoundError("beer not found"))
+	pricingRuleService := services.NewPricingRuleService(nil, mockBeerRepository)
+
+	rule, err := pricingRuleService.CreatePricingRule(givenPricingRule(&beerID))
+
+	assert.Nil(t, rule)
+	assert.Equal(t, expectedError, err)
+	mockBeerRepository.AssertExpectations(t)
+}
+
+func Test_CreatePricingRule_WhenProcessIsExecutedSuccessfully_ThenReturnRuleWithId(t *testing.T) {
+	beerID := int64(1)
+	mockBeerRepository := new(services.MockBeerRepository)
+	mockBeerRepository.On("GetByID", beerID).Return(givenBeer(), nil)
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("Save", givenPricingRule(&beerID)).Return(int64(4), nil)
+	pricingRuleService := services.NewPricingRuleService(mockPricingRuleRepository, mockBeerRepository)
+
+	rule, err := pricingRuleService.CreatePricingRule(givenPricingRule(&beerID))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), rule.Id)
+	mockBeerRepository.AssertExpectations(t)
+	mockPricingRuleRepository.AssertExpectations(t)
+}
+
+func Test_CreatePricingRule_WhenRuleIsGlobal_ThenDoNotLookUpBeer(t *testing.T) {
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("Save", mock.Anything).Return(int64(5), nil)
+	mockBeerRepository := new(services.MockBeerRepository)
+	pricingRuleService := services.NewPricingRuleService(mockPricingRuleRepository, mockBeerRepository)
+
+	rule, err := pricingRuleService.CreatePricingRule(givenPricingRule(nil))
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), rule.Id)
+	mockBeerRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func Test_UpdatePricingRule_WhenBodyIdDoesNotMatch_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewBadRequestError("body Id 2 does not match pricing rule id 1")
+	pricingRuleService := services.NewPricingRuleService(nil, nil)
+	rule := givenPricingRule(nil)
+	rule.Id = 2
+
+	updatedRule, err := pricingRuleService.UpdatePricingRule(1, rule)
+
+	assert.Nil(t, updatedRule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_UpdatePricingRule_WhenProcessIsExecutedSuccessfully_ThenReturnRule(t *testing.T) {
+	expectedRule := givenPricingRule(nil)
+	expectedRule.Id = 1
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("Update", expectedRule).Return(nil)
+	pricingRuleService := services.NewPricingRuleService(mockPricingRuleRepository, nil)
+
+	rule, err := pricingRuleService.UpdatePricingRule(1, givenPricingRule(nil))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRule, *rule)
+	mockPricingRuleRepository.AssertExpectations(t)
+}
+
+func Test_DeletePricingRule_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewNotFoundError("pricing rule not found")
+	mockPricingRuleRepository := new(services.MockPricingRuleRepository)
+	mockPricingRuleRepository.On("Delete", int64(1)).Return(expectedError)
+	pricingRuleService := services.NewPricingRuleService(mockPricingRuleRepository, nil)
+
+	err := pricingRuleService.DeletePricingRule(1)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func givenPricingRule(beerID *int64) entities.PricingRule {
+	return entities.PricingRule{
+		BeerId:      beerID,
+		Name:        "volume",
+		Type:        entities.PercentagePricingRule,
+		MinQuantity: 12,
+		Percentage:  decimal.NewFromInt(5),
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type taxRuleService struct {
+	taxRuleRepository TaxRuleRepository
+}
+
+func NewTaxRuleService(taxRuleRepository TaxRuleRepository) *taxRuleService {
+	return &taxRuleService{
+		taxRuleRepository: taxRuleRepository,
+	}
+}
+
+func (s *taxRuleService) ListTaxRules() ([]entities.TaxRule, *errors.RestError) {
+	return s.taxRuleRepository.List()
+}
+
+func (s *taxRuleService) GetTaxRuleByID(ruleID int64) (*entities.TaxRule, *errors.RestError) {
+	return s.taxRuleRepository.GetByID(ruleID)
+}
+
+func (s *taxRuleService) CreateTaxRule(rule entities.TaxRule) (*entities.TaxRule, *errors.RestError) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	ruleID, err := s.taxRuleRepository.Save(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Id = ruleID
+	return &rule, nil
+}
+
+func (s *taxRuleService) UpdateTaxRule(ruleID int64, rule entities.TaxRule) (*entities.TaxRule, *errors.RestError) {
+	if rule.Id != 0 && rule.Id != ruleID {
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("body Id %d does not match tax rule id %d", rule.Id, ruleID))
+	}
+
+	rule.Id = ruleID
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.taxRuleRepository.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (s *taxRuleService) DeleteTaxRule(ruleID int64) *errors.RestError {
+	return s.taxRuleRepository.Delete(ruleID)
+}
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CreateTaxRule_WhenRuleIsInvalid_ThenReturnError(t *testing.T) {
+	rule := givenTaxRule()
+	rule.Country = "Germany"
+	expectedError := errors.NewBadRequestError("invalid Country: Germany, it must be an ISO 3166-1 alpha-2 code")
+	taxRuleService := services.NewTaxRuleService(nil)
+
+	createdRule, err := taxRuleService.CreateTaxRule(rule)
+
+	assert.Nil(t, createdRule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_CreateTaxRule_WhenProcessIsExecutedSuccessfully_ThenReturnRuleWithNormalizedCountry(t *testing.T) {
+	rule := givenTaxRule()
+	rule.Country = "de"
+	savedRule := givenTaxRule()
+	mockTaxRuleRepository := new(services.MockTaxRuleRepository)
+	mockTaxRuleRepository.On("Save", savedRule).Return(int64(4), nil)
+	taxRuleService := services.NewTaxRuleService(mockTaxRuleRepository)
+
+	createdRule, err := taxRuleService.CreateTaxRule(rule)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), createdRule.Id)
+	assert.Equal(t, "DE", createdRule.Country)
+}
+
+func Test_UpdateTaxRule_WhenBodyIdDoesNotMatchRuleID_ThenReturnError(t *testing.T) {
+	rule := givenTaxRule()
+	rule.Id = 5
+	expectedError := errors.NewBadRequestError("body Id 5 does not match tax rule id 4")
+	taxRuleService := services.NewTaxRuleService(nil)
+
+	updatedRule, err := taxRuleService.UpdateTaxRule(4, rule)
+
+	assert.Nil(t, updatedRule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_UpdateTaxRule_WhenRepositoryFail_ThenReturnError(t *testing.T) {
+	rule := givenTaxRule()
+	expectedRule := givenTaxRule()
+	expectedRule.Id = 4
+	expectedError := errors.NewNotFoundError("tax rule not found")
+	mockTaxRuleRepository := new(services.MockTaxRuleRepository)
+	mockTaxRuleRepository.On("Update", expectedRule).Return(expectedError)
+	taxRuleService := services.NewTaxRuleService(mockTaxRuleRepository)
+
+	updatedRule, err := taxRuleService.UpdateTaxRule(4, rule)
+
+	assert.Nil(t, updatedRule)
+	assert.Equal(t, expectedError, err)
+}
+
+func givenTaxRule() entities.TaxRule {
+	return entities.TaxRule{
+		Country:    "DE",
+		Category:   entities.GeneralTaxCategory,
+		Name:       "VAT",
+		Type:       entities.PercentageTax,
+		Percentage: decimal.NewFromInt(19),
+	}
+}
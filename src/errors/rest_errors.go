@@ -34,6 +34,14 @@ func NewNotFoundError(message string) *RestError {
 	}
 }
 
+func NewRequestEntityTooLargeError(message string) *RestError {
+	return &RestError{
+		Message: message,
+		Status:  http.StatusRequestEntityTooLarge,
+		Error:   "request_entity_too_large",
+	}
+}
+
 func NewInternalServerError(message string) *RestError {
 	return &RestError{
 		Message: message,
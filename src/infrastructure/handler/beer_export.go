@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dleonsal/beers-api/src/core/contracts"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+const (
+	csvExportFormat    = "csv"
+	ndjsonExportFormat = "ndjson"
+	// exportFlushRows is the number of rows written between flushes of the
+	// response, so the client receives the export while it is produced.
+	exportFlushRows = 100
+)
+
+var beerExportColumns = []string{"id", "name", "brewery", "country", "price", "currency"}
+
+// beerExportWriter writes the rows of an export in one of the export formats.
+type beerExportWriter interface {
+	ContentType() string
+	WriteHeader() error
+	Write(export entities.BeerExport) error
+	Flush() error
+}
+
+func newBeerExportWriter(format string, converted bool, w io.Writer) (beerExportWriter, *errors.RestError) {
+	switch format {
+	case csvExportFormat, "":
+		return &csvBeerExportWriter{writer: csv.NewWriter(w), converted: converted}, nil
+	case ndjsonExportFormat:
+		return &ndjsonBeerExportWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("invalid format: %s, it must be %s or %s", format, csvExportFormat, ndjsonExportFormat))
+	}
+}
+
+// csvBeerExportWriter uses the columns of the import, so an export can be
+// imported back.
+type csvBeerExportWriter struct {
+	writer    *csv.Writer
+	converted bool
+}
+
+func (w *csvBeerExportWriter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (w *csvBeerExportWriter) WriteHeader() error {
+	header := beerExportColumns
+	if w.converted {
+		header = append(header[:len(header):len(header)], "converted_price", "converted_currency")
+	}
+
+	return w.writer.Write(header)
+}
+
+func (w *csvBeerExportWriter) Write(export entities.BeerExport) error {
+	beer := export.Beer
+	record := []string{fmt.Sprint(beer.Id), beer.Name, beer.Brewery, beer.Country, beer.Price.String(), beer.Currency}
+	if export.ConvertedPrice != nil {
+		converted := export.ConvertedPrice
+		record = append(record, converted.Amount.StringFixed(money.MinorUnits(converted.Currency)), converted.Currency)
+	}
+
+	return w.writer.Write(record)
+}
+
+func (w *csvBeerExportWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonBeerExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonBeerExportWriter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (w *ndjsonBeerExportWriter) WriteHeader() error {
+	return nil
+}
+
+func (w *ndjsonBeerExportWriter) Write(export entities.BeerExport) error {
+	beer := export.Beer
+	return w.encoder.Encode(contracts.BeerExportResponse{
+		Id:             beer.Id,
+		Name:           beer.Name,
+		Brewery:        beer.Brewery,
+		Country:        beer.Country,
+		Price:          beer.Price,
+		Currency:       beer.Currency,
+		ConvertedPrice: export.ConvertedPrice,
+	})
+}
+
+func (w *ndjsonBeerExportWriter) Flush() error {
+	return nil
+}
@@ -4,47 +4,67 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/dleonsal/beers-api/src/core/contracts"
 	"github.com/dleonsal/beers-api/src/core/domain/entities"
 	"github.com/dleonsal/beers-api/src/errors"
 	"github.com/dleonsal/beers-api/src/infrastructure/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
+const totalCountHeader = "X-Total-Count"
+
 type BeerService interface {
-	ListBeers() ([]entities.Beer, *errors.RestError)
+	ListBeers(query entities.BeerQuery) ([]entities.Beer, int64, *errors.RestError)
 	GetBeerByID(beerID int64) (*entities.Beer, *errors.RestError)
-	GetBoxPrice(beerID int64, newCurrency string, quantity uint64) (float64, *errors.RestError)
-	CreateBeer(beer entities.Beer) *errors.RestError
+	GetBoxPrice(beerID int64, newCurrency string, quantity uint64, options entities.BoxPriceOptions) (*entities.BoxPriceQuote, *errors.RestError)
+	GetBoxPrices(beerID int64, newCurrencies []string, quantity uint64, options entities.BoxPriceOptions) ([]entities.BoxPriceResult, *errors.RestError)
+	CreateBeer(beer entities.Beer) (*entities.Beer, *errors.RestError)
+	ExportBeers(query entities.BeerQuery, newCurrency string, write func(export entities.BeerExport) *errors.RestError) *errors.RestError
+	ImportBeers(rows []entities.BeerImportRow, mode string) (*entities.BeerImportReport, *errors.RestError)
+	UpdateBeer(beerID int64, beer entities.Beer) (*entities.Beer, *errors.RestError)
+	PatchBeer(beerID int64, patch []byte) (*entities.Beer, *errors.RestError)
+	DeleteBeer(beerID int64) *errors.RestError
 }
 
 type beerHandler struct {
-	beerService BeerService
+	beerService        BeerService
+	maxImportBodyBytes int64
 }
 
-func NewBeerHandler(beenService BeerService) *beerHandler {
+// NewBeerHandler returns the beer handler. Import bodies larger than
+// maxImportBodyBytes are rejected.
+func NewBeerHandler(beenService BeerService, maxImportBodyBytes int64) *beerHandler {
 	return &beerHandler{
-		beerService: beenService,
+		beerService:        beenService,
+		maxImportBodyBytes: maxImportBodyBytes,
 	}
 }
 
 func (h *beerHandler) HandleList(c *gin.Context) {
-	beers, restErr := h.beerService.ListBeers()
+	query, restErr := parseBeerQuery(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	beers, total, restErr := h.beerService.ListBeers(query)
 	if restErr != nil {
 		c.JSON(restErr.Status, restErr)
 
 		return
 	}
 
+	c.Header(totalCountHeader, strconv.FormatInt(total, 10))
 	c.JSON(http.StatusOK, beers)
 }
 
 func (h *beerHandler) HandleGetByID(c *gin.Context) {
-	beerID, err := strconv.ParseInt(c.Param("beer_id"), 10, 64)
-	if err != nil {
-		logger.Log.Error(fmt.Sprintf("error trying to parse param beer id to int64: %s", err))
-		restErr := errors.NewBadRequestError("id should be a number")
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
 		c.JSON(restErr.Status, restErr)
 
 		return
@@ -61,10 +81,8 @@ func (h *beerHandler) HandleGetByID(c *gin.Context) {
 }
 
 func (h *beerHandler) HandleGetBoxPrice(c *gin.Context) {
-	beerID, err := strconv.ParseInt(c.Param("beer_id"), 10, 64)
-	if err != nil {
-		logger.Log.Error(fmt.Sprintf("error trying to parse param beer id to int64: %s", err))
-		restErr := errors.NewBadRequestError("id should be a number")
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
 		c.JSON(restErr.Status, restErr)
 
 		return
@@ -72,25 +90,50 @@ func (h *beerHandler) HandleGetBoxPrice(c *gin.Context) {
 
 	currency := c.Query("currency")
 
-	quantity, err := strconv.ParseUint(c.Query("quantity"), 10, 64)
-	if err != nil {
-		logger.Log.Error(fmt.Sprintf("error trying to parse beer param quantity to uint64: %s", err))
-		restErr := errors.NewBadRequestError("quantity should be a positive number")
-		c.JSON(restErr.Status, restErr)
+	var quantity uint64
+	if rawQuantity := c.Query("quantity"); rawQuantity != "" || c.Query("pack") == "" {
+		value, err := strconv.ParseUint(rawQuantity, 10, 64)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to parse beer param quantity to uint64: %s", err))
+			restErr := errors.NewBadRequestError("quantity should be a positive number")
+			c.JSON(restErr.Status, restErr)
+
+			return
+		}
+		quantity = value
+	}
+
+	options := entities.BoxPriceOptions{
+		Coupon:      c.Query("coupon"),
+		Destination: c.Query("destination"),
+	}
+
+	if pack := c.Query("pack"); pack != "" {
+		value, err := strconv.ParseUint(pack, 10, 64)
+		if err != nil || value == 0 {
+			logger.Log.Error(fmt.Sprintf("error trying to parse beer param pack to uint64: %s", pack))
+			restErr := errors.NewBadRequestError("pack should be a positive number")
+			c.JSON(restErr.Status, restErr)
+
+			return
+		}
+		options.Pack = value
+	}
+
+	if currencies := c.Query("currencies"); currencies != "" {
+		h.handleGetBoxPrices(c, beerID, strings.Split(currencies, ","), quantity, options)
 
 		return
 	}
 
-	totalPrice, restErr := h.beerService.GetBoxPrice(beerID, currency, quantity)
+	quote, restErr := h.beerService.GetBoxPrice(beerID, currency, quantity, options)
 	if restErr != nil {
 		c.JSON(restErr.Status, restErr)
 
 		return
 	}
 
-	c.JSON(http.StatusOK, contracts.BoxPriceResponse{
-		TotalPrice: totalPrice,
-	})
+	c.JSON(http.StatusOK, newBoxPriceResponse(quote))
 
 }
 
@@ -105,12 +148,329 @@ func (h *beerHandler) HandleCreate(c *gin.Context) {
 		return
 	}
 
-	restErr := h.beerService.CreateBeer(request)
+	beer, restErr := h.beerService.CreateBeer(request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/beers/%d", beer.Id))
+	c.JSON(http.StatusCreated, beer)
+}
+
+func (h *beerHandler) HandleImport(c *gin.Context) {
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, h.maxImportBodyBytes)
+	rows, restErr := parseBeerImport(c.ContentType(), body)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	report, restErr := h.beerService.ImportBeers(rows, c.Query("mode"))
 	if restErr != nil {
 		c.JSON(restErr.Status, restErr)
 
 		return
 	}
 
-	c.JSON(http.StatusCreated, "Beer created")
+	if report.Mode == entities.AllOrNothingImport && report.Failed > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// HandleExport streams the beers matching the list filters as csv or ndjson.
+// The response is committed with the first row, so an error found after it can
+// only be logged.
+func (h *beerHandler) HandleExport(c *gin.Context) {
+	query, restErr := parseBeerQuery(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	format := c.DefaultQuery("format", csvExportFormat)
+	targetCurrency := c.Query("target_currency")
+	writer, restErr := newBeerExportWriter(format, targetCurrency != "", c.Writer)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	started := false
+	start := func() *errors.RestError {
+		started = true
+		c.Header("Content-Type", writer.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=beers.%s", format))
+		c.Status(http.StatusOK)
+		if err := writer.WriteHeader(); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to write beers export header: %s", err))
+			return errors.NewInternalServerError("error trying to write beers export")
+		}
+
+		return nil
+	}
+
+	written := 0
+	restErr = h.beerService.ExportBeers(query, targetCurrency, func(export entities.BeerExport) *errors.RestError {
+		if !started {
+			if restErr := start(); restErr != nil {
+				return restErr
+			}
+		}
+
+		if err := writer.Write(export); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to write beer %d to export: %s", export.Beer.Id, err))
+			return errors.NewInternalServerError("error trying to write beers export")
+		}
+
+		written++
+		if written%exportFlushRows == 0 {
+			return flushBeerExport(c, writer)
+		}
+
+		return nil
+	})
+	if restErr != nil {
+		if !started {
+			c.JSON(restErr.Status, restErr)
+
+			return
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to export beers after %d rows: %s", written, restErr.Message))
+		return
+	}
+
+	if !started {
+		if restErr := start(); restErr != nil {
+			return
+		}
+	}
+
+	_ = flushBeerExport(c, writer)
+}
+
+func (h *beerHandler) HandleUpdate(c *gin.Context) {
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	var request entities.Beer
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	beer, restErr := h.beerService.UpdateBeer(beerID, request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, beer)
+}
+
+func (h *beerHandler) HandlePatch(c *gin.Context) {
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to read request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	beer, restErr := h.beerService.PatchBeer(beerID, patch)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, beer)
+}
+
+func (h *beerHandler) HandleDelete(c *gin.Context) {
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	if restErr := h.beerService.DeleteBeer(beerID); restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func flushBeerExport(c *gin.Context, writer beerExportWriter) *errors.RestError {
+	if err := writer.Flush(); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to flush beers export: %s", err))
+		return errors.NewInternalServerError("error trying to write beers export")
+	}
+	c.Writer.Flush()
+
+	return nil
+}
+
+func parseBeerID(c *gin.Context) (int64, *errors.RestError) {
+	beerID, err := strconv.ParseInt(c.Param("beer_id"), 10, 64)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to parse param beer id to int64: %s", err))
+		return 0, errors.NewBadRequestError("id should be a number")
+	}
+
+	return beerID, nil
+}
+
+func parseBeerQuery(c *gin.Context) (entities.BeerQuery, *errors.RestError) {
+	query := entities.NewBeerQuery()
+	query.Country = c.Query("country")
+	query.Brewery = c.Query("brewery")
+	query.Currency = c.Query("currency")
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to parse param limit to int: %s", err))
+			return query, errors.NewBadRequestError("limit should be a number")
+		}
+		query.Limit = value
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to parse param offset to int: %s", err))
+			return query, errors.NewBadRequestError("offset should be a number")
+		}
+		query.Offset = value
+	}
+
+	if minPrice := c.Query("min_price"); minPrice != "" {
+		value, err := decimal.NewFromString(minPrice)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to parse param min_price to decimal: %s", err))
+			return query, errors.NewBadRequestError("min_price should be a number")
+		}
+		query.MinPrice = &value
+	}
+
+	if maxPrice := c.Query("max_price"); maxPrice != "" {
+		value, err := decimal.NewFromString(maxPrice)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to parse param max_price to decimal: %s", err))
+			return query, errors.NewBadRequestError("max_price should be a number")
+		}
+		query.MaxPrice = &value
+	}
+
+	sort, restErr := entities.ParseSort(c.Query("sort"))
+	if restErr != nil {
+		return query, restErr
+	}
+	query.Sort = sort
+
+	return query, nil
+}
+
+func (h *beerHandler) handleGetBoxPrices(c *gin.Context, beerID int64, currencies []string, quantity uint64, options entities.BoxPriceOptions) {
+	results, restErr := h.beerService.GetBoxPrices(beerID, currencies, quantity, options)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	response := contracts.BoxPricesResponse{
+		BoxPrices: make([]contracts.BoxPriceResultResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		resultResponse := contracts.BoxPriceResultResponse{
+			Currency: result.Currency,
+			Error:    result.Err,
+		}
+		if result.Quote != nil {
+			quote := newBoxPriceResponse(result.Quote)
+			resultResponse.Quote = &quote
+		}
+
+		response.BoxPrices = append(response.BoxPrices, resultResponse)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func newBoxPriceResponse(quote *entities.BoxPriceQuote) contracts.BoxPriceResponse {
+	response := contracts.BoxPriceResponse{
+		BeerID:         quote.BeerID,
+		SourceCurrency: quote.SourceCurrency,
+		TargetCurrency: quote.TargetCurrency,
+		ExchangeRate:   quote.ExchangeRate,
+		UnitPrice:      quote.UnitPrice,
+		Quantity:       quote.Quantity,
+		Subtotal:       quote.Subtotal,
+		Discounts:      make([]contracts.DiscountLineResponse, 0, len(quote.Discounts)),
+		Destination:    quote.Destination,
+		NetPrice:       quote.NetPrice,
+		Taxes:          make([]contracts.TaxLineResponse, 0, len(quote.Taxes)),
+		TotalPrice:     quote.TotalPrice,
+		RateProvider:   quote.RateProvider,
+	}
+	for _, discount := range quote.Discounts {
+		response.Discounts = append(response.Discounts, contracts.DiscountLineResponse{
+			RuleID:      discount.RuleID,
+			CouponCode:  discount.CouponCode,
+			Description: discount.Description,
+			Amount:      discount.Amount,
+		})
+	}
+	if quote.Pack != nil {
+		packagingCost := quote.PackagingCost
+		response.Pack = &contracts.PackResponse{
+			Id:   quote.Pack.Id,
+			Name: quote.Pack.Name,
+			Size: quote.Pack.Size,
+		}
+		response.PackagingCost = &packagingCost
+	}
+	for _, tax := range quote.Taxes {
+		response.Taxes = append(response.Taxes, contracts.TaxLineResponse{
+			RuleID:      tax.RuleID,
+			Description: tax.Description,
+			Amount:      tax.Amount,
+		})
+	}
+	if !quote.RateTimestamp.IsZero() {
+		rateTimestamp := quote.RateTimestamp
+		response.RateTimestamp = &rateTimestamp
+	}
+
+	return response
 }
@@ -8,21 +8,27 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dleonsal/beers-api/src/core/contracts"
 	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/domain/money"
 	"github.com/dleonsal/beers-api/src/errors"
 	"github.com/dleonsal/beers-api/src/infrastructure/handler"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+const maxImportBodyBytesTest int64 = 10 << 20
+
 func Test_HandleList_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
 	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers", nil, nil, "")
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("ListBeers").Return(nil, expectedError)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("ListBeers", entities.NewBeerQuery()).Return(nil, int64(0), expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleList(ctx)
 
@@ -35,8 +41,8 @@ func Test_HandleList_WhenProcessIsExecutedCorrectly_ThenReturnBeersAndStatusCode
 	expectedBeer := givenBeer()
 	expectedBeers := []entities.Beer{*expectedBeer}
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("ListBeers").Return(expectedBeers, nil)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("ListBeers", entities.NewBeerQuery()).Return(expectedBeers, int64(1), nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleList(ctx)
 
@@ -44,14 +50,74 @@ func Test_HandleList_WhenProcessIsExecutedCorrectly_ThenReturnBeersAndStatusCode
 	json.Unmarshal(recorder.Body.Bytes(), beers)
 
 	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "1", recorder.Header().Get("X-Total-Count"))
 	assert.Equal(t, expectedBeers, *beers)
 }
 
+func Test_HandleList_WhenParamLimitIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	queryParams := url.Values{"limit": {"invalid"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers", nil, &queryParams, "")
+	expectedError := errors.NewBadRequestError("limit should be a number")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleList(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleList_WhenParamSortIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	queryParams := url.Values{"sort": {"price,-color"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers", nil, &queryParams, "")
+	expectedError := errors.NewBadRequestError("invalid sort field: color")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleList(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleList_WhenQueryParamsAreSent_ThenListBeersWithQuery(t *testing.T) {
+	queryParams := url.Values{
+		"limit":     {"10"},
+		"offset":    {"30"},
+		"country":   {"Colombia"},
+		"brewery":   {"Bavaria"},
+		"currency":  {"COP"},
+		"min_price": {"1000"},
+		"max_price": {"3000.5"},
+		"sort":      {"price,-name"},
+	}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers", nil, &queryParams, "")
+	minPrice := decimal.RequireFromString("1000")
+	maxPrice := decimal.RequireFromString("3000.5")
+	expectedQuery := entities.BeerQuery{
+		Limit:    10,
+		Offset:   30,
+		Country:  "Colombia",
+		Brewery:  "Bavaria",
+		Currency: "COP",
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+		Sort:     []entities.SortField{{Field: "price"}, {Field: "name", Descending: true}},
+	}
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ListBeers", expectedQuery).Return([]entities.Beer{}, int64(35), nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleList(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "35", recorder.Header().Get("X-Total-Count"))
+	mockBeerService.AssertExpectations(t)
+}
+
 func Test_HandleGetByID_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
 	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id",
 		[]gin.Param{{Key: "beer_id", Value: "invalid"}}, nil, "")
 	expectedError := errors.NewBadRequestError("id should be a number")
-	handler := handler.NewBeerHandler(nil)
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
 
 	handler.HandleGetByID(ctx)
 
@@ -66,7 +132,7 @@ func Test_HandleGetByID_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *test
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerService := new(handler.MockBeerService)
 	mockBeerService.On("GetBeerByID", id).Return(nil, expectedError)
-	handler := handler.NewBeerHandler(mockBeerService)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleGetByID(ctx)
 
@@ -80,7 +146,7 @@ func Test_HandleGetByID_WhenProcessIsExecutedCorrectly_ThenReturnBeerAndStatusCo
 		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(expectedBeer.Id)}}, nil, "")
 	mockBeerService := new(handler.MockBeerService)
 	mockBeerService.On("GetBeerByID", expectedBeer.Id).Return(expectedBeer, nil)
-	handler := handler.NewBeerHandler(mockBeerService)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleGetByID(ctx)
 
@@ -95,7 +161,7 @@ func Test_HandleGetBoxPrice_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCod
 	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
 		[]gin.Param{{Key: "beer_id", Value: "invalid"}}, nil, "")
 	expectedError := errors.NewBadRequestError("id should be a number")
-	handler := handler.NewBeerHandler(nil)
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
 
 	handler.HandleGetBoxPrice(ctx)
 
@@ -108,7 +174,7 @@ func Test_HandleGetBoxPrice_WhenParamQuantityIsInvalid_ThenReturnErrorAndStatusC
 	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
 		[]gin.Param{{Key: "beer_id", Value: "1"}}, &queryParams, "")
 	expectedError := errors.NewBadRequestError("quantity should be a positive number")
-	handler := handler.NewBeerHandler(nil)
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
 
 	handler.HandleGetBoxPrice(ctx)
 
@@ -125,8 +191,8 @@ func Test_HandleGetBoxPrice_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *
 		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity).Return(0.0, expectedError)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity, entities.BoxPriceOptions{}).Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleGetBoxPrice(ctx)
 
@@ -134,20 +200,57 @@ func Test_HandleGetBoxPrice_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *
 	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
 }
 
-func Test_HandleGetBoxPrice_WhenProcessIsExecutedCorrectly_ThenReturnErrorAndStatusCode(t *testing.T) {
+func Test_HandleGetBoxPrice_WhenProcessIsExecutedCorrectly_ThenReturnQuoteAndStatusCode(t *testing.T) {
 	id := int64(1)
 	newCurrency := "USD"
 	quantity := uint64(10)
-	queryParams := url.Values{"currency": {newCurrency}, "quantity": {fmt.Sprint(quantity)}}
-	expectedTotalPrice := 10.0
+	queryParams := url.Values{"currency": {newCurrency}, "quantity": {fmt.Sprint(quantity)}, "destination": {"DE"}}
+	rateTimestamp := time.Date(2021, 11, 19, 10, 30, 0, 0, time.UTC)
+	quote := &entities.BoxPriceQuote{
+		BeerID:         id,
+		SourceCurrency: "COP",
+		TargetCurrency: newCurrency,
+		ExchangeRate:   decimal.RequireFromString("0.00025"),
+		UnitPrice:      money.New(decimal.RequireFromString("1.00"), newCurrency),
+		Quantity:       quantity,
+		Subtotal:       money.New(decimal.RequireFromString("10.50"), newCurrency),
+		Discounts: []entities.DiscountLine{
+			{RuleID: 7, Description: "volume: 5% off", Amount: money.New(decimal.RequireFromString("0.50"), newCurrency)},
+		},
+		Destination: "DE",
+		NetPrice:    money.New(decimal.RequireFromString("10.00"), newCurrency),
+		Taxes: []entities.TaxLine{
+			{RuleID: 3, Description: "VAT: 19%", Amount: money.New(decimal.RequireFromString("1.90"), newCurrency)},
+		},
+		TotalPrice:    money.New(decimal.RequireFromString("11.90"), newCurrency),
+		RateProvider:  "rapidapi",
+		RateTimestamp: rateTimestamp,
+	}
 	expectedResponse := contracts.BoxPriceResponse{
-		TotalPrice: expectedTotalPrice,
+		BeerID:         id,
+		SourceCurrency: "COP",
+		TargetCurrency: newCurrency,
+		ExchangeRate:   decimal.RequireFromString("0.00025"),
+		UnitPrice:      money.New(decimal.RequireFromString("1.00"), newCurrency),
+		Quantity:       quantity,
+		Subtotal:       money.New(decimal.RequireFromString("10.50"), newCurrency),
+		Discounts: []contracts.DiscountLineResponse{
+			{RuleID: 7, Description: "volume: 5% off", Amount: money.New(decimal.RequireFromString("0.50"), newCurrency)},
+		},
+		Destination: "DE",
+		NetPrice:    money.New(decimal.RequireFromString("10.00"), newCurrency),
+		Taxes: []contracts.TaxLineResponse{
+			{RuleID: 3, Description: "VAT: 19%", Amount: money.New(decimal.RequireFromString("1.90"), newCurrency)},
+		},
+		TotalPrice:    money.New(decimal.RequireFromString("11.90"), newCurrency),
+		RateProvider:  "rapidapi",
+		RateTimestamp: &rateTimestamp,
 	}
 	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
 		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity).Return(expectedTotalPrice, nil)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity, entities.BoxPriceOptions{Destination: "DE"}).Return(quote, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleGetBoxPrice(ctx)
 
@@ -158,11 +261,164 @@ func Test_HandleGetBoxPrice_WhenProcessIsExecutedCorrectly_ThenReturnErrorAndSta
 	assert.Equal(t, expectedResponse, *boxPriceResponse)
 }
 
+func Test_HandleGetBoxPrice_WhenNoConversionWasNeeded_ThenOmitRateProviderAndTimestamp(t *testing.T) {
+	id := int64(1)
+	newCurrency := "COP"
+	quantity := uint64(2)
+	queryParams := url.Values{"currency": {newCurrency}, "quantity": {fmt.Sprint(quantity)}}
+	expectedBody := `{"Beer Id":1,"Source Currency":"COP","Target Currency":"COP","Exchange Rate":1,` +
+		`"Unit Price":{"Amount":2500,"Currency":"COP"},"Quantity":2,"Subtotal":{"Amount":5000,"Currency":"COP"},"Discounts":[],` +
+		`"Net Price":{"Amount":5000,"Currency":"COP"},"Taxes":[],"Total Price":{"Amount":5000,"Currency":"COP"}}`
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity, entities.BoxPriceOptions{}).Return(&entities.BoxPriceQuote{
+		BeerID:         id,
+		SourceCurrency: newCurrency,
+		TargetCurrency: newCurrency,
+		ExchangeRate:   decimal.NewFromInt(1),
+		UnitPrice:      money.New(decimal.NewFromInt(2500), newCurrency),
+		Quantity:       quantity,
+		Subtotal:       money.New(decimal.NewFromInt(5000), newCurrency),
+		NetPrice:       money.New(decimal.NewFromInt(5000), newCurrency),
+		TotalPrice:     money.New(decimal.NewFromInt(5000), newCurrency),
+	}, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, expectedBody, recorder.Body.String())
+}
+
+func Test_HandleGetBoxPrice_WhenCouponParam_ThenPassItToBeerService(t *testing.T) {
+	id := int64(1)
+	newCurrency := "COP"
+	quantity := uint64(6)
+	queryParams := url.Values{"currency": {newCurrency}, "quantity": {fmt.Sprint(quantity)}, "coupon": {"SUMMER"}}
+	expectedError := errors.NewBadRequestError("coupon SUMMER has expired")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("GetBoxPrice", id, newCurrency, quantity, entities.BoxPriceOptions{Coupon: "SUMMER"}).
+		Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+	mockBeerService.AssertExpectations(t)
+}
+
+func Test_HandleGetBoxPrice_WhenParamPackIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	queryParams := url.Values{"currency": {"COP"}, "pack": {"0"}}
+	expectedError := errors.NewBadRequestError("pack should be a positive number")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: "1"}}, &queryParams, "")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleGetBoxPrice_WhenPackParamWithoutQuantity_ThenReturnQuoteWithPack(t *testing.T) {
+	id := int64(1)
+	newCurrency := "COP"
+	queryParams := url.Values{"currency": {newCurrency}, "pack": {"12"}}
+	expectedBody := `{"Beer Id":1,"Source Currency":"COP","Target Currency":"COP","Exchange Rate":1,` +
+		`"Unit Price":{"Amount":2500,"Currency":"COP"},"Quantity":12,"Subtotal":{"Amount":30000,"Currency":"COP"},"Discounts":[],` +
+		`"Pack":{"Id":2,"Name":"12-pack","Size":12},"Packaging Cost":{"Amount":1500,"Currency":"COP"},` +
+		`"Net Price":{"Amount":31500,"Currency":"COP"},"Taxes":[],"Total Price":{"Amount":31500,"Currency":"COP"}}`
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("GetBoxPrice", id, newCurrency, uint64(0), entities.BoxPriceOptions{Pack: 12}).Return(&entities.BoxPriceQuote{
+		BeerID:         id,
+		SourceCurrency: newCurrency,
+		TargetCurrency: newCurrency,
+		ExchangeRate:   decimal.NewFromInt(1),
+		UnitPrice:      money.New(decimal.NewFromInt(2500), newCurrency),
+		Quantity:       12,
+		Subtotal:       money.New(decimal.NewFromInt(30000), newCurrency),
+		Pack:           &entities.Pack{Id: 2, BeerId: id, Name: "12-pack", Size: 12, Surcharge: decimal.NewFromInt(1500), Currency: newCurrency},
+		PackagingCost:  money.New(decimal.NewFromInt(1500), newCurrency),
+		NetPrice:       money.New(decimal.NewFromInt(31500), newCurrency),
+		TotalPrice:     money.New(decimal.NewFromInt(31500), newCurrency),
+	}, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, expectedBody, recorder.Body.String())
+}
+
+func Test_HandleGetBoxPrice_WhenCurrenciesParamAndBeerServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	id := int64(1)
+	quantity := uint64(12)
+	queryParams := url.Values{"currencies": {"USD,EUR"}, "quantity": {fmt.Sprint(quantity)}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("GetBoxPrices", id, []string{"USD", "EUR"}, quantity, entities.BoxPriceOptions{}).Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleGetBoxPrice_WhenCurrenciesParam_ThenReturnResultPerCurrency(t *testing.T) {
+	id := int64(1)
+	quantity := uint64(12)
+	queryParams := url.Values{"currencies": {"COP,EUR"}, "quantity": {fmt.Sprint(quantity)}}
+	expectedBody := `{"Box Prices":[` +
+		`{"Currency":"COP","Quote":{"Beer Id":1,"Source Currency":"COP","Target Currency":"COP","Exchange Rate":1,` +
+		`"Unit Price":{"Amount":2500,"Currency":"COP"},"Quantity":12,"Subtotal":{"Amount":30000,"Currency":"COP"},"Discounts":[],` +
+		`"Net Price":{"Amount":30000,"Currency":"COP"},"Taxes":[],"Total Price":{"Amount":30000,"Currency":"COP"}}},` +
+		`{"Currency":"EUR","Error":{"message":"some error","status":500,"error":"internal_server_error"}}]}`
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/boxprice",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, &queryParams, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("GetBoxPrices", id, []string{"COP", "EUR"}, quantity, entities.BoxPriceOptions{}).Return([]entities.BoxPriceResult{
+		{
+			Currency: "COP",
+			Quote: &entities.BoxPriceQuote{
+				BeerID:         id,
+				SourceCurrency: "COP",
+				TargetCurrency: "COP",
+				ExchangeRate:   decimal.NewFromInt(1),
+				UnitPrice:      money.New(decimal.NewFromInt(2500), "COP"),
+				Quantity:       quantity,
+				Subtotal:       money.New(decimal.NewFromInt(30000), "COP"),
+				NetPrice:       money.New(decimal.NewFromInt(30000), "COP"),
+				TotalPrice:     money.New(decimal.NewFromInt(30000), "COP"),
+			},
+		},
+		{
+			Currency: "EUR",
+			Err:      errors.NewInternalServerError("some error"),
+		},
+	}, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleGetBoxPrice(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, expectedBody, recorder.Body.String())
+	mockBeerService.AssertNotCalled(t, "GetBoxPrice", id, "", quantity, entities.BoxPriceOptions{})
+}
+
 func Test_HandleCreate_WhenBodyIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
 	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers",
 		nil, nil, "{,}")
 	expectedError := errors.NewBadRequestError("invalid json body")
-	handler := handler.NewBeerHandler(nil)
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
 
 	handler.HandleCreate(ctx)
 
@@ -177,8 +433,8 @@ func Test_HandleCreate_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testi
 		nil, nil, string(bodyBytes))
 	expectedError := errors.NewInternalServerError("some error")
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("CreateBeer", *beer).Return(expectedError)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("CreateBeer", *beer).Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleCreate(ctx)
 
@@ -186,19 +442,372 @@ func Test_HandleCreate_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testi
 	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
 }
 
-func Test_HandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnErrorAndStatusCode(t *testing.T) {
-	beer := givenBeer()
-	bodyBytes, _ := json.Marshal(beer)
+func Test_HandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnBeerAndLocation(t *testing.T) {
+	request := givenBeer()
+	request.Id = 0
+	bodyBytes, _ := json.Marshal(request)
 	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers",
 		nil, nil, string(bodyBytes))
+	expectedBeer := *request
+	expectedBeer.Id = 7
 	mockBeerService := new(handler.MockBeerService)
-	mockBeerService.On("CreateBeer", *beer).Return(nil)
-	handler := handler.NewBeerHandler(mockBeerService)
+	mockBeerService.On("CreateBeer", *request).Return(&expectedBeer, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
 
 	handler.HandleCreate(ctx)
 
+	beer := new(entities.Beer)
+	json.Unmarshal(recorder.Body.Bytes(), beer)
+
 	assert.Equal(t, http.StatusCreated, recorder.Code)
-	assert.Equal(t, "\"Beer created\"", recorder.Body.String())
+	assert.Equal(t, "/beers/7", recorder.Header().Get("Location"))
+	assert.Equal(t, &expectedBeer, beer)
+}
+
+func Test_HandleImport_WhenContentTypeIsNotSupported_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("unsupported content type: text/plain, it must be text/csv or application/json")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, nil, "some")
+	ctx.Request.Header.Set("Content-Type", "text/plain")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleImport(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleImport_WhenCSVMissesAColumn_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("missing csv column: price")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, nil,
+		"name,brewery,country,currency\nPilsen,Bavaria,Colombia,COP\n")
+	ctx.Request.Header.Set("Content-Type", "text/csv")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleImport(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleImport_WhenJSONBodyIsTooLarge_ThenReturnRequestEntityTooLarge(t *testing.T) {
+	expectedError := errors.NewRequestEntityTooLargeError("import body is larger than 16 bytes")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, nil,
+		`[{"Name":"Pilsen","Brewery":"Bavaria","Country":"Colombia","Price":2500,"Currency":"COP"}]`)
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	handler := handler.NewBeerHandler(nil, 16)
+
+	handler.HandleImport(ctx)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleImport_WhenCSVBodyIsTooLarge_ThenReturnRequestEntityTooLarge(t *testing.T) {
+	expectedError := errors.NewRequestEntityTooLargeError("import body is larger than 48 bytes")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, nil,
+		"Name,Brewery,Country,Price,Currency\n"+
+			"Pilsen,Bavaria,Colombia,2500,COP\n"+
+			"Club,Bavaria,Colombia,3000,COP\n")
+	ctx.Request.Header.Set("Content-Type", "text/csv")
+	handler := handler.NewBeerHandler(nil, 48)
+
+	handler.HandleImport(ctx)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleImport_WhenCSVIsValid_ThenParseRowsAndReturnReport(t *testing.T) {
+	queryParams := url.Values{"mode": {entities.BestEffortImport}}
+	body := "Name,Brewery,Country,Price,Currency,Id\n" +
+		"Pilsen,Bavaria,Colombia,2500,COP,\n" +
+		"Club,Bavaria,Colombia,abc,COP,\n" +
+		"Aguila,Bavaria,Colombia\n"
+	rows := []entities.BeerImportRow{
+		{Row: 1, Beer: entities.Beer{Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(2500), Currency: "COP"}},
+		{Row: 2, Beer: entities.Beer{Name: "Club", Brewery: "Bavaria", Country: "Colombia", Currency: "COP"}, Err: errors.NewBadRequestError("invalid Price: abc")},
+		{Row: 3, Err: errors.NewBadRequestError("row has 3 fields, expected 6")},
+	}
+	report := &entities.BeerImportReport{
+		Mode:     entities.BestEffortImport,
+		Total:    3,
+		Imported: 1,
+		Failed:   2,
+		Rows: []entities.BeerImportResult{
+			{Row: 1, Id: 7},
+			{Row: 2, Error: errors.NewBadRequestError("invalid Price: abc")},
+			{Row: 3, Error: errors.NewBadRequestError("row has 3 fields, expected 6")},
+		},
+	}
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, &queryParams, body)
+	ctx.Request.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ImportBeers", rows, entities.BestEffortImport).Return(report, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleImport(ctx)
+
+	importReport := new(entities.BeerImportReport)
+	json.Unmarshal(recorder.Body.Bytes(), importReport)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, report, importReport)
+}
+
+func Test_HandleImport_WhenAllOrNothingImportFail_ThenReturnReportAndUnprocessableEntity(t *testing.T) {
+	body := `[{"Name":"Pilsen","Brewery":"Bavaria","Country":"Colombia","Price":2500,"Currency":"COP"},{"Name":1}]`
+	rows := []entities.BeerImportRow{
+		{Row: 1, Beer: entities.Beer{Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(2500), Currency: "COP"}},
+		{Row: 2, Err: errors.NewBadRequestError("invalid beer: json: cannot unmarshal number into Go struct field Beer.Name of type string")},
+	}
+	report := &entities.BeerImportReport{
+		Mode:   entities.AllOrNothingImport,
+		Total:  2,
+		Failed: 1,
+		Rows: []entities.BeerImportResult{
+			{Row: 1},
+			{Row: 2, Error: rows[1].Err},
+		},
+	}
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/beers/import", nil, nil, body)
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ImportBeers", rows, "").Return(report, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleImport(ctx)
+
+	importReport := new(entities.BeerImportReport)
+	json.Unmarshal(recorder.Body.Bytes(), importReport)
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Equal(t, report, importReport)
+}
+
+func Test_HandleExport_WhenParamFormatIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	queryParams := url.Values{"format": {"xml"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/export", nil, &queryParams, "")
+	expectedError := errors.NewBadRequestError("invalid format: xml, it must be csv or ndjson")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleExport(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleExport_WhenBeerServiceFailBeforeFirstRow_ThenReturnErrorAndStatusCode(t *testing.T) {
+	queryParams := url.Values{"target_currency": {"USD"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/export", nil, &queryParams, "")
+	expectedError := errors.NewInternalServerError("some error")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ExportBeers", entities.NewBeerQuery(), "USD", mock.Anything).Return(expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleExport(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleExport_WhenFormatIsCSVAndTargetCurrency_ThenStreamConvertedRows(t *testing.T) {
+	queryParams := url.Values{"country": {"Colombia"}, "target_currency": {"USD"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/export", nil, &queryParams, "")
+	expectedQuery := entities.NewBeerQuery()
+	expectedQuery.Country = "Colombia"
+	beer := givenBeer()
+	convertedPrice := money.New(decimal.RequireFromString("0.6"), "USD")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ExportBeers", expectedQuery, "USD", mock.Anything).Return(
+		func(_ entities.BeerQuery, _ string, write func(export entities.BeerExport) *errors.RestError) *errors.RestError {
+			return write(entities.BeerExport{Beer: *beer, ConvertedPrice: &convertedPrice})
+		})
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleExport(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=beers.csv", recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,name,brewery,country,price,currency,converted_price,converted_currency\n"+
+		"1,Pilsen,Bavaria,Colombia,2500,COP,0.60,USD\n", recorder.Body.String())
+}
+
+func Test_HandleExport_WhenFormatIsNDJSON_ThenStreamOneObjectPerLine(t *testing.T) {
+	queryParams := url.Values{"format": {"ndjson"}}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/export", nil, &queryParams, "")
+	beer := givenBeer()
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ExportBeers", entities.NewBeerQuery(), "", mock.Anything).Return(
+		func(_ entities.BeerQuery, _ string, write func(export entities.BeerExport) *errors.RestError) *errors.RestError {
+			if err := write(entities.BeerExport{Beer: *beer}); err != nil {
+				return err
+			}
+			return write(entities.BeerExport{Beer: *beer})
+		})
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleExport(ctx)
+
+	line := `{"Id":1,"Name":"Pilsen","Brewery":"Bavaria","Country":"Colombia","Price":2500,"Currency":"COP"}` + "\n"
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, line+line, recorder.Body.String())
+}
+
+func Test_HandleExport_WhenNoBeerMatches_ThenReturnOnlyCSVHeader(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/export", nil, nil, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("ExportBeers", entities.NewBeerQuery(), "", mock.Anything).Return(nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleExport(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "id,name,brewery,country,price,currency\n", recorder.Body.String())
+}
+
+func Test_HandleUpdate_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: "invalid"}}, nil, "")
+	expectedError := errors.NewBadRequestError("id should be a number")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleUpdate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleUpdate_WhenBodyIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: "1"}}, nil, "{,}")
+	expectedError := errors.NewBadRequestError("invalid json body")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleUpdate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleUpdate_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	beer := givenBeer()
+	bodyBytes, _ := json.Marshal(beer)
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(beer.Id)}}, nil, string(bodyBytes))
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("UpdateBeer", beer.Id, *beer).Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleUpdate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleUpdate_WhenProcessIsExecutedCorrectly_ThenReturnBeerAndStatusCode200(t *testing.T) {
+	expectedBeer := givenBeer()
+	bodyBytes, _ := json.Marshal(expectedBeer)
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(expectedBeer.Id)}}, nil, string(bodyBytes))
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("UpdateBeer", expectedBeer.Id, *expectedBeer).Return(expectedBeer, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleUpdate(ctx)
+
+	beer := new(entities.Beer)
+	json.Unmarshal(recorder.Body.Bytes(), beer)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expectedBeer, beer)
+}
+
+func Test_HandlePatch_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodPatch, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: "invalid"}}, nil, "")
+	expectedError := errors.NewBadRequestError("id should be a number")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandlePatch(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandlePatch_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	id := int64(1)
+	patch := `{"Price": 3000}`
+	ctx, recorder := givenContextAndRecorder(http.MethodPatch, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, nil, patch)
+	expectedError := errors.NewBadRequestError("patch must be a json object")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("PatchBeer", id, []byte(patch)).Return(nil, expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandlePatch(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandlePatch_WhenProcessIsExecutedCorrectly_ThenReturnBeerAndStatusCode200(t *testing.T) {
+	expectedBeer := givenBeer()
+	patch := `{"Price": 2500}`
+	ctx, recorder := givenContextAndRecorder(http.MethodPatch, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(expectedBeer.Id)}}, nil, patch)
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("PatchBeer", expectedBeer.Id, []byte(patch)).Return(expectedBeer, nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandlePatch(ctx)
+
+	beer := new(entities.Beer)
+	json.Unmarshal(recorder.Body.Bytes(), beer)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expectedBeer, beer)
+}
+
+func Test_HandleDelete_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: "invalid"}}, nil, "")
+	expectedError := errors.NewBadRequestError("id should be a number")
+	handler := handler.NewBeerHandler(nil, maxImportBodyBytesTest)
+
+	handler.HandleDelete(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleDelete_WhenBeerServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	id := int64(1)
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, nil, "")
+	expectedError := errors.NewNotFoundError("beer not found")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("DeleteBeer", id).Return(expectedError)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleDelete(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_HandleDelete_WhenProcessIsExecutedCorrectly_ThenReturnStatusCode204(t *testing.T) {
+	id := int64(1)
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/beers/:beer_id",
+		[]gin.Param{{Key: "beer_id", Value: fmt.Sprint(id)}}, nil, "")
+	mockBeerService := new(handler.MockBeerService)
+	mockBeerService.On("DeleteBeer", id).Return(nil)
+	handler := handler.NewBeerHandler(mockBeerService, maxImportBodyBytesTest)
+
+	handler.HandleDelete(ctx)
+
+	assert.Equal(t, http.StatusNoContent, ctx.Writer.Status())
+	assert.Empty(t, recorder.Body.String())
 }
 
 func givenContextAndRecorder(method, url string, params []gin.Param, queryParams *url.Values, body string) (*gin.Context, *httptest.ResponseRecorder) {
@@ -230,7 +839,7 @@ func givenBeer() *entities.Beer {
 		Name:     "Pilsen",
 		Brewery:  "Bavaria",
 		Country:  "Colombia",
-		Price:    2500,
+		Price:    decimal.NewFromInt(2500),
 		Currency: "COP",
 	}
 }
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	genericerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	csvContentType  = "text/csv"
+	jsonContentType = "application/json"
+)
+
+var requiredImportColumns = []string{"name", "brewery", "country", "price", "currency"}
+
+// parseBeerImport reads the rows of an import body in CSV, with a header row,
+// or as a JSON array of beers. A row that can not be parsed gets its own error
+// instead of failing the whole import.
+func parseBeerImport(contentType string, body io.Reader) ([]entities.BeerImportRow, *errors.RestError) {
+	switch contentType {
+	case csvContentType:
+		return parseBeerImportCSV(body)
+	case jsonContentType, "":
+		return parseBeerImportJSON(body)
+	default:
+		return nil, errors.NewBadRequestError(
+			fmt.Sprintf("unsupported content type: %s, it must be %s or %s", contentType, csvContentType, jsonContentType))
+	}
+}
+
+func parseBeerImportJSON(body io.Reader) ([]entities.BeerImportRow, *errors.RestError) {
+	var objects []json.RawMessage
+	if err := json.NewDecoder(body).Decode(&objects); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to decode import body: %s", err))
+		return nil, importBodyError(err, "invalid json body, it must be an array of beers")
+	}
+
+	rows := make([]entities.BeerImportRow, len(objects))
+	for i, object := range objects {
+		rows[i].Row = i + 1
+		if err := json.Unmarshal(object, &rows[i].Beer); err != nil {
+			rows[i].Err = errors.NewBadRequestError(fmt.Sprintf("invalid beer: %s", err))
+		}
+	}
+
+	return rows, nil
+}
+
+func parseBeerImportCSV(body io.Reader) ([]entities.BeerImportRow, *errors.RestError) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to read import csv header: %s", err))
+		return nil, importBodyError(err, "invalid csv body, it must start with a header row")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, column := range requiredImportColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, errors.NewBadRequestError(fmt.Sprintf("missing csv column: %s", column))
+		}
+	}
+
+	rows := make([]entities.BeerImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if genericerrors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to read import csv: %s", err))
+			return nil, importBodyError(err, "invalid csv body")
+		}
+
+		row := entities.BeerImportRow{Row: len(rows) + 1}
+		if len(record) != len(header) {
+			row.Err = errors.NewBadRequestError(
+				fmt.Sprintf("row has %d fields, expected %d", len(record), len(header)))
+		} else {
+			row.Beer, row.Err = parseCSVBeer(record, columns)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseCSVBeer(record []string, columns map[string]int) (entities.Beer, *errors.RestError) {
+	beer := entities.Beer{
+		Name:     record[columns["name"]],
+		Brewery:  record[columns["brewery"]],
+		Country:  record[columns["country"]],
+		Currency: record[columns["currency"]],
+	}
+
+	if i, ok := columns["id"]; ok && record[i] != "" {
+		id, err := strconv.ParseInt(record[i], 10, 64)
+		if err != nil {
+			return beer, errors.NewBadRequestError(fmt.Sprintf("invalid Id: %s", record[i]))
+		}
+		beer.Id = id
+	}
+
+	price, err := decimal.NewFromString(record[columns["price"]])
+	if err != nil {
+		return beer, errors.NewBadRequestError(fmt.Sprintf("invalid Price: %s", record[columns["price"]]))
+	}
+	beer.Price = price
+
+	return beer, nil
+}
+
+// importBodyError maps an error reading the import body to a 413 when the body
+// is over the limit, and to a bad request with message otherwise.
+func importBodyError(err error, message string) *errors.RestError {
+	var maxBytesErr *http.MaxBytesError
+	if genericerrors.As(err, &maxBytesErr) {
+		return errors.NewRequestEntityTooLargeError(
+			fmt.Sprintf("import body is larger than %d bytes", maxBytesErr.Limit))
+	}
+
+	return errors.NewBadRequestError(message)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type CouponService interface {
+	ListCoupons() ([]entities.Coupon, *errors.RestError)
+	GetCouponByID(couponID int64) (*entities.Coupon, *errors.RestError)
+	CreateCoupon(coupon entities.Coupon) (*entities.Coupon, *errors.RestError)
+	UpdateCoupon(couponID int64, coupon entities.Coupon) (*entities.Coupon, *errors.RestError)
+	DeleteCoupon(couponID int64) *errors.RestError
+	RedeemCoupon(couponID int64) (*entities.Coupon, *errors.RestError)
+}
+
+type couponHandler struct {
+	couponService CouponService
+}
+
+func NewCouponHandler(couponService CouponService) *couponHandler {
+	return &couponHandler{
+		couponService: couponService,
+	}
+}
+
+func (h *couponHandler) HandleList(c *gin.Context) {
+	coupons, restErr := h.couponService.ListCoupons()
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, coupons)
+}
+
+func (h *couponHandler) HandleGetByID(c *gin.Context) {
+	couponID, restErr := parseCouponID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	coupon, restErr := h.couponService.GetCouponByID(couponID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, coupon)
+}
+
+func (h *couponHandler) HandleCreate(c *gin.Context) {
+	var request entities.Coupon
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	coupon, restErr := h.couponService.CreateCoupon(request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/coupons/%d", coupon.Id))
+	c.JSON(http.StatusCreated, coupon)
+}
+
+func (h *couponHandler) HandleUpdate(c *gin.Context) {
+	couponID, restErr := parseCouponID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	var request entities.Coupon
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	coupon, restErr := h.couponService.UpdateCoupon(couponID, request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, coupon)
+}
+
+func (h *couponHandler) HandleDelete(c *gin.Context) {
+	couponID, restErr := parseCouponID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	if restErr := h.couponService.DeleteCoupon(couponID); restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *couponHandler) HandleRedeem(c *gin.Context) {
+	couponID, restErr := parseCouponID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	coupon, restErr := h.couponService.RedeemCoupon(couponID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, coupon)
+}
+
+func parseCouponID(c *gin.Context) (int64, *errors.RestError) {
+	couponID, err := strconv.ParseInt(c.Param("coupon_id"), 10, 64)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to parse param coupon id to int64: %s", err))
+		return 0, errors.NewBadRequestError("id should be a number")
+	}
+
+	return couponID, nil
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CouponHandleCreate_WhenBodyIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid json body")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/coupons", nil, nil, "{")
+	handler := handler.NewCouponHandler(nil)
+
+	handler.HandleCreate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_CouponHandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnCouponAndLocation(t *testing.T) {
+	coupon := givenCoupon()
+	request := *coupon
+	request.Id = 0
+	body, _ := json.Marshal(request)
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/coupons", nil, nil, string(body))
+	mockCouponService := new(handler.MockCouponService)
+	mockCouponService.On("CreateCoupon", request).Return(coupon, nil)
+	handler := handler.NewCouponHandler(mockCouponService)
+
+	handler.HandleCreate(ctx)
+
+	createdCoupon := new(entities.Coupon)
+	json.Unmarshal(recorder.Body.Bytes(), createdCoupon)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "/coupons/2", recorder.Header().Get("Location"))
+	assert.Equal(t, coupon, createdCoupon)
+}
+
+func Test_CouponHandleGetByID_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewNotFoundError("coupon not found")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/coupons/:coupon_id",
+		[]gin.Param{{Key: "coupon_id", Value: "2"}}, nil, "")
+	mockCouponService := new(handler.MockCouponService)
+	mockCouponService.On("GetCouponByID", int64(2)).Return(nil, expectedError)
+	handler := handler.NewCouponHandler(mockCouponService)
+
+	handler.HandleGetByID(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_CouponHandleRedeem_WhenParamCouponIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("id should be a number")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/coupons/:coupon_id/redemptions",
+		[]gin.Param{{Key: "coupon_id", Value: "abc"}}, nil, "")
+	handler := handler.NewCouponHandler(nil)
+
+	handler.HandleRedeem(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_CouponHandleRedeem_WhenProcessIsExecutedCorrectly_ThenReturnCoupon(t *testing.T) {
+	coupon := givenCoupon()
+	coupon.Uses = 1
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/coupons/:coupon_id/redemptions",
+		[]gin.Param{{Key: "coupon_id", Value: "2"}}, nil, "")
+	mockCouponService := new(handler.MockCouponService)
+	mockCouponService.On("RedeemCoupon", int64(2)).Return(coupon, nil)
+	handler := handler.NewCouponHandler(mockCouponService)
+
+	handler.HandleRedeem(ctx)
+
+	redeemedCoupon := new(entities.Coupon)
+	json.Unmarshal(recorder.Body.Bytes(), redeemedCoupon)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, coupon, redeemedCoupon)
+}
+
+func givenCoupon() *entities.Coupon {
+	return &entities.Coupon{
+		Id:         2,
+		Code:       "SUMMER",
+		Type:       entities.PercentageCoupon,
+		Percentage: decimal.NewFromInt(10),
+		Amount:     decimal.NewFromInt(0),
+		MaxUses:    100,
+		Brewery:    "Bavaria",
+	}
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/gin-gonic/gin"
+)
+
+type currencyHandler struct{}
+
+func NewCurrencyHandler() *currencyHandler {
+	return &currencyHandler{}
+}
+
+func (h *currencyHandler) HandleList(c *gin.Context) {
+	c.JSON(http.StatusOK, currency.All())
+}
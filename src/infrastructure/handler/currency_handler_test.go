@@ -0,0 +1,25 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/currency"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HandleListCurrencies_WhenProcessIsExecutedCorrectly_ThenReturnCurrenciesAndStatusCode200(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/currencies", nil, nil, "")
+	handler := handler.NewCurrencyHandler()
+
+	handler.HandleList(ctx)
+
+	currencies := new([]currency.Currency)
+	json.Unmarshal(recorder.Body.Bytes(), currencies)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, currency.All(), *currencies)
+	assert.Contains(t, *currencies, currency.Currency{Code: "COP", Name: "Colombian Peso", MinorUnits: 2})
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dleonsal/beers-api/src/core/contracts"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthService interface {
+	Readiness(ctx context.Context) contracts.HealthResponse
+}
+
+type healthHandler struct {
+	healthService HealthService
+}
+
+func NewHealthHandler(healthService HealthService) *healthHandler {
+	return &healthHandler{
+		healthService: healthService,
+	}
+}
+
+// HandleLive reports that the process serves requests, without checking its
+// dependencies.
+func (h *healthHandler) HandleLive(c *gin.Context) {
+	c.JSON(http.StatusOK, contracts.HealthResponse{Status: contracts.HealthStatusUp})
+}
+
+// HandleReady reports the status of every dependency, with a 503 when a
+// critical one is down.
+func (h *healthHandler) HandleReady(c *gin.Context) {
+	health := h.healthService.Readiness(c.Request.Context())
+	if health.Status == contracts.HealthStatusDown {
+		c.JSON(http.StatusServiceUnavailable, health)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, health)
+}
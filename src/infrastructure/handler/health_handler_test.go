@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/contracts"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HandleLive_ThenReturnUpAndStatusCode200(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/health/live", nil, nil, "")
+	mockHealthService := new(handler.MockHealthService)
+	handler := handler.NewHealthHandler(mockHealthService)
+
+	handler.HandleLive(ctx)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"Status":"up"}`, recorder.Body.String())
+	mockHealthService.AssertNotCalled(t, "Readiness", mock.Anything)
+}
+
+func Test_HandleReady_WhenNonCriticalDependencyIsDown_ThenReturnHealthAndStatusCode200(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/health/ready", nil, nil, "")
+	health := givenHealth(contracts.HealthStatusDegraded, false)
+	mockHealthService := new(handler.MockHealthService)
+	mockHealthService.On("Readiness", mock.Anything).Return(health)
+	handler := handler.NewHealthHandler(mockHealthService)
+
+	handler.HandleReady(ctx)
+
+	response := new(contracts.HealthResponse)
+	json.Unmarshal(recorder.Body.Bytes(), response)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, health, *response)
+}
+
+func Test_HandleReady_WhenCriticalDependencyIsDown_ThenReturnHealthAndStatusCode503(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/health/ready", nil, nil, "")
+	health := givenHealth(contracts.HealthStatusDown, true)
+	mockHealthService := new(handler.MockHealthService)
+	mockHealthService.On("Readiness", mock.Anything).Return(health)
+	handler := handler.NewHealthHandler(mockHealthService)
+
+	handler.HandleReady(ctx)
+
+	response := new(contracts.HealthResponse)
+	json.Unmarshal(recorder.Body.Bytes(), response)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, health, *response)
+}
+
+func givenHealth(status string, critical bool) contracts.HealthResponse {
+	return contracts.HealthResponse{
+		Status: status,
+		Dependencies: []contracts.DependencyHealthResponse{{
+			Name:                "mysql",
+			Status:              contracts.HealthStatusDown,
+			Critical:            critical,
+			LatencyMilliseconds: 1.5,
+			Error:               "connection refused",
+		}},
+	}
+}
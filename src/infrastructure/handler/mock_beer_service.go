@@ -15,12 +15,53 @@ type MockBeerService struct {
 }
 
 // CreateBeer provides a mock function with given fields: beer
-func (_m *MockBeerService) CreateBeer(beer entities.Beer) *errors.RestError {
+func (_m *MockBeerService) CreateBeer(beer entities.Beer) (*entities.Beer, *errors.RestError) {
 	ret := _m.Called(beer)
 
-	var r0 *errors.RestError
-	if rf, ok := ret.Get(0).(func(entities.Beer) *errors.RestError); ok {
+	var r0 *entities.Beer
+	if rf, ok := ret.Get(0).(func(entities.Beer) *entities.Beer); ok {
 		r0 = rf(beer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Beer)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(entities.Beer) *errors.RestError); ok {
+		r1 = rf(beer)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// DeleteBeer provides a mock function with given fields: beerID
+func (_m *MockBeerService) DeleteBeer(beerID int64) *errors.RestError {
+	ret := _m.Called(beerID)
+
+	var r0 *errors.RestError
+	if rf, ok := ret.Get(0).(func(int64) *errors.RestError); ok {
+		r0 = rf(beerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*errors.RestError)
+		}
+	}
+
+	return r0
+}
+
+// ExportBeers provides a mock function with given fields: query, newCurrency, write
+func (_m *MockBeerService) ExportBeers(query entities.BeerQuery, newCurrency string, write func(entities.BeerExport) *errors.RestError) *errors.RestError {
+	ret := _m.Called(query, newCurrency, write)
+
+	var r0 *errors.RestError
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery, string, func(entities.BeerExport) *errors.RestError) *errors.RestError); ok {
+		r0 = rf(query, newCurrency, write)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*errors.RestError)
@@ -55,20 +96,72 @@ func (_m *MockBeerService) GetBeerByID(beerID int64) (*entities.Beer, *errors.Re
 	return r0, r1
 }
 
-// GetBoxPrice provides a mock function with given fields: beerID, newCurrency, quantity
-func (_m *MockBeerService) GetBoxPrice(beerID int64, newCurrency string, quantity uint64) (float64, *errors.RestError) {
-	ret := _m.Called(beerID, newCurrency, quantity)
+// GetBoxPrice provides a mock function with given fields: beerID, newCurrency, quantity, options
+func (_m *MockBeerService) GetBoxPrice(beerID int64, newCurrency string, quantity uint64, options entities.BoxPriceOptions) (*entities.BoxPriceQuote, *errors.RestError) {
+	ret := _m.Called(beerID, newCurrency, quantity, options)
+
+	var r0 *entities.BoxPriceQuote
+	if rf, ok := ret.Get(0).(func(int64, string, uint64, entities.BoxPriceOptions) *entities.BoxPriceQuote); ok {
+		r0 = rf(beerID, newCurrency, quantity, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.BoxPriceQuote)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(int64, string, uint64, entities.BoxPriceOptions) *errors.RestError); ok {
+		r1 = rf(beerID, newCurrency, quantity, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetBoxPrices provides a mock function with given fields: beerID, newCurrencies, quantity, options
+func (_m *MockBeerService) GetBoxPrices(beerID int64, newCurrencies []string, quantity uint64, options entities.BoxPriceOptions) ([]entities.BoxPriceResult, *errors.RestError) {
+	ret := _m.Called(beerID, newCurrencies, quantity, options)
+
+	var r0 []entities.BoxPriceResult
+	if rf, ok := ret.Get(0).(func(int64, []string, uint64, entities.BoxPriceOptions) []entities.BoxPriceResult); ok {
+		r0 = rf(beerID, newCurrencies, quantity, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.BoxPriceResult)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(int64, []string, uint64, entities.BoxPriceOptions) *errors.RestError); ok {
+		r1 = rf(beerID, newCurrencies, quantity, options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// ImportBeers provides a mock function with given fields: rows, mode
+func (_m *MockBeerService) ImportBeers(rows []entities.BeerImportRow, mode string) (*entities.BeerImportReport, *errors.RestError) {
+	ret := _m.Called(rows, mode)
 
-	var r0 float64
-	if rf, ok := ret.Get(0).(func(int64, string, uint64) float64); ok {
-		r0 = rf(beerID, newCurrency, quantity)
+	var r0 *entities.BeerImportReport
+	if rf, ok := ret.Get(0).(func([]entities.BeerImportRow, string) *entities.BeerImportReport); ok {
+		r0 = rf(rows, mode)
 	} else {
-		r0 = ret.Get(0).(float64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.BeerImportReport)
+		}
 	}
 
 	var r1 *errors.RestError
-	if rf, ok := ret.Get(1).(func(int64, string, uint64) *errors.RestError); ok {
-		r1 = rf(beerID, newCurrency, quantity)
+	if rf, ok := ret.Get(1).(func([]entities.BeerImportRow, string) *errors.RestError); ok {
+		r1 = rf(rows, mode)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.RestError)
@@ -78,22 +171,79 @@ func (_m *MockBeerService) GetBoxPrice(beerID int64, newCurrency string, quantit
 	return r0, r1
 }
 
-// ListBeers provides a mock function with given fields:
-func (_m *MockBeerService) ListBeers() ([]entities.Beer, *errors.RestError) {
-	ret := _m.Called()
+// ListBeers provides a mock function with given fields: query
+func (_m *MockBeerService) ListBeers(query entities.BeerQuery) ([]entities.Beer, int64, *errors.RestError) {
+	ret := _m.Called(query)
 
 	var r0 []entities.Beer
-	if rf, ok := ret.Get(0).(func() []entities.Beer); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(entities.BeerQuery) []entities.Beer); ok {
+		r0 = rf(query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entities.Beer)
 		}
 	}
 
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(entities.BeerQuery) int64); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 *errors.RestError
+	if rf, ok := ret.Get(2).(func(entities.BeerQuery) *errors.RestError); ok {
+		r2 = rf(query)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).(*errors.RestError)
+		}
+	}
+
+	return r0, r1, r2
+}
+
+// PatchBeer provides a mock function with given fields: beerID, patch
+func (_m *MockBeerService) PatchBeer(beerID int64, patch []byte) (*entities.Beer, *errors.RestError) {
+	ret := _m.Called(beerID, patch)
+
+	var r0 *entities.Beer
+	if rf, ok := ret.Get(0).(func(int64, []byte) *entities.Beer); ok {
+		r0 = rf(beerID, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Beer)
+		}
+	}
+
+	var r1 *errors.RestError
+	if rf, ok := ret.Get(1).(func(int64, []byte) *errors.RestError); ok {
+		r1 = rf(beerID, patch)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*errors.RestError)
+		}
+	}
+
+	return r0, r1
+}
+
+// UpdateBeer provides a mock function with given fields: beerID, beer
+func (_m *MockBeerService) UpdateBeer(beerID int64, beer entities.Beer) (*entities.Beer, *errors.RestError) {
+	ret := _m.Called(beerID, beer)
+
+	var r0 *entities.Beer
+	if rf, ok := ret.Get(0).(func(int64, entities.Beer) *entities.Beer); ok {
+		r0 = rf(beerID, beer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Beer)
+		}
+	}
+
 	var r1 *errors.RestError
-	if rf, ok := ret.Get(1).(func() *errors.RestError); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(int64, entities.Beer) *errors.RestError); ok {
+		r1 = rf(beerID, beer)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*errors.RestError)
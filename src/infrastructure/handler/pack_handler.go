@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type PackService interface {
+	ListPacks() ([]entities.Pack, *errors.RestError)
+	ListBeerPacks(beerID int64) ([]entities.Pack, *errors.RestError)
+	GetPackByID(packID int64) (*entities.Pack, *errors.RestError)
+	CreatePack(pack entities.Pack) (*entities.Pack, *errors.RestError)
+	UpdatePack(packID int64, pack entities.Pack) (*entities.Pack, *errors.RestError)
+	DeletePack(packID int64) *errors.RestError
+}
+
+type packHandler struct {
+	packService PackService
+}
+
+func NewPackHandler(packService PackService) *packHandler {
+	return &packHandler{
+		packService: packService,
+	}
+}
+
+func (h *packHandler) HandleList(c *gin.Context) {
+	packs, restErr := h.packService.ListPacks()
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, packs)
+}
+
+func (h *packHandler) HandleListByBeer(c *gin.Context) {
+	beerID, restErr := parseBeerID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	packs, restErr := h.packService.ListBeerPacks(beerID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, packs)
+}
+
+func (h *packHandler) HandleGetByID(c *gin.Context) {
+	packID, restErr := parsePackID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	pack, restErr := h.packService.GetPackByID(packID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pack)
+}
+
+func (h *packHandler) HandleCreate(c *gin.Context) {
+	var request entities.Pack
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	pack, restErr := h.packService.CreatePack(request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/packs/%d", pack.Id))
+	c.JSON(http.StatusCreated, pack)
+}
+
+func (h *packHandler) HandleUpdate(c *gin.Context) {
+	packID, restErr := parsePackID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	var request entities.Pack
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	pack, restErr := h.packService.UpdatePack(packID, request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pack)
+}
+
+func (h *packHandler) HandleDelete(c *gin.Context) {
+	packID, restErr := parsePackID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	if restErr := h.packService.DeletePack(packID); restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parsePackID(c *gin.Context) (int64, *errors.RestError) {
+	packID, err := strconv.ParseInt(c.Param("pack_id"), 10, 64)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to parse param pack id to int64: %s", err))
+		return 0, errors.NewBadRequestError("id should be a number")
+	}
+
+	return packID, nil
+}
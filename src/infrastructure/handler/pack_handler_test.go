@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PackHandleListByBeer_WhenParamBeerIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("id should be a number")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/packs",
+		[]gin.Param{{Key: "beer_id", Value: "abc"}}, nil, "")
+	handler := handler.NewPackHandler(nil)
+
+	handler.HandleListByBeer(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_PackHandleListByBeer_WhenProcessIsExecutedCorrectly_ThenReturnPacks(t *testing.T) {
+	expectedPacks := []entities.Pack{*givenPack()}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/beers/:beer_id/packs",
+		[]gin.Param{{Key: "beer_id", Value: "1"}}, nil, "")
+	mockPackService := new(handler.MockPackService)
+	mockPackService.On("ListBeerPacks", int64(1)).Return(expectedPacks, nil)
+	handler := handler.NewPackHandler(mockPackService)
+
+	handler.HandleListByBeer(ctx)
+
+	var packs []entities.Pack
+	json.Unmarshal(recorder.Body.Bytes(), &packs)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expectedPacks, packs)
+}
+
+func Test_PackHandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnPackAndLocation(t *testing.T) {
+	pack := givenPack()
+	request := *pack
+	request.Id = 0
+	body, _ := json.Marshal(request)
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/packs", nil, nil, string(body))
+	mockPackService := new(handler.MockPackService)
+	mockPackService.On("CreatePack", request).Return(pack, nil)
+	handler := handler.NewPackHandler(mockPackService)
+
+	handler.HandleCreate(ctx)
+
+	createdPack := new(entities.Pack)
+	json.Unmarshal(recorder.Body.Bytes(), createdPack)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "/packs/2", recorder.Header().Get("Location"))
+	assert.Equal(t, pack, createdPack)
+}
+
+func Test_PackHandleDelete_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewNotFoundError("pack not found")
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/packs/:pack_id",
+		[]gin.Param{{Key: "pack_id", Value: "2"}}, nil, "")
+	mockPackService := new(handler.MockPackService)
+	mockPackService.On("DeletePack", int64(2)).Return(expectedError)
+	handler := handler.NewPackHandler(mockPackService)
+
+	handler.HandleDelete(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func givenPack() *entities.Pack {
+	return &entities.Pack{
+		Id:        2,
+		BeerId:    1,
+		Name:      "12-pack",
+		Size:      12,
+		Surcharge: decimal.NewFromInt(1500),
+		Currency:  "COP",
+	}
+}
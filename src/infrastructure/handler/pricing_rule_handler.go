@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type PricingRuleService interface {
+	ListPricingRules() ([]entities.PricingRule, *errors.RestError)
+	GetPricingRuleByID(ruleID int64) (*entities.PricingRule, *errors.RestError)
+	CreatePricingRule(rule entities.PricingRule) (*entities.PricingRule, *errors.RestError)
+	UpdatePricingRule(ruleID int64, rule entities.PricingRule) (*entities.PricingRule, *errors.RestError)
+	DeletePricingRule(ruleID int64) *errors.RestError
+}
+
+type pricingRuleHandler struct {
+	pricingRuleService PricingRuleService
+}
+
+func NewPricingRuleHandler(pricingRuleService PricingRuleService) *pricingRuleHandler {
+	return &pricingRuleHandler{
+		pricingRuleService: pricingRuleService,
+	}
+}
+
+func (h *pricingRuleHandler) HandleList(c *gin.Context) {
+	rules, restErr := h.pricingRuleService.ListPricingRules()
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *pricingRuleHandler) HandleGetByID(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.pricingRuleService.GetPricingRuleByID(ruleID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *pricingRuleHandler) HandleCreate(c *gin.Context) {
+	var request entities.PricingRule
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.pricingRuleService.CreatePricingRule(request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/pricing-rules/%d", rule.Id))
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *pricingRuleHandler) HandleUpdate(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	var request entities.PricingRule
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.pricingRuleService.UpdatePricingRule(ruleID, request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *pricingRuleHandler) HandleDelete(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	if restErr := h.pricingRuleService.DeletePricingRule(ruleID); restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func parseRuleID(c *gin.Context) (int64, *errors.RestError) {
+	ruleID, err := strconv.ParseInt(c.Param("rule_id"), 10, 64)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to parse param rule id to int64: %s", err))
+		return 0, errors.NewBadRequestError("id should be a number")
+	}
+
+	return ruleID, nil
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PricingRuleHandleList_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewInternalServerError("some error")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/pricing-rules", nil, nil, "")
+	mockPricingRuleService := new(handler.MockPricingRuleService)
+	mockPricingRuleService.On("ListPricingRules").Return(nil, expectedError)
+	handler := handler.NewPricingRuleHandler(mockPricingRuleService)
+
+	handler.HandleList(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_PricingRuleHandleList_WhenProcessIsExecutedCorrectly_ThenReturnRules(t *testing.T) {
+	expectedRules := []entities.PricingRule{*givenPricingRule()}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/pricing-rules", nil, nil, "")
+	mockPricingRuleService := new(handler.MockPricingRuleService)
+	mockPricingRuleService.On("ListPricingRules").Return(expectedRules, nil)
+	handler := handler.NewPricingRuleHandler(mockPricingRuleService)
+
+	handler.HandleList(ctx)
+
+	var rules []entities.PricingRule
+	json.Unmarshal(recorder.Body.Bytes(), &rules)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expectedRules, rules)
+}
+
+func Test_PricingRuleHandleGetByID_WhenParamRuleIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("id should be a number")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/pricing-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "abc"}}, nil, "")
+	handler := handler.NewPricingRuleHandler(nil)
+
+	handler.HandleGetByID(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_PricingRuleHandleCreate_WhenBodyIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid json body")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/pricing-rules", nil, nil, "{")
+	handler := handler.NewPricingRuleHandler(nil)
+
+	handler.HandleCreate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_PricingRuleHandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnRuleAndLocation(t *testing.T) {
+	rule := givenPricingRule()
+	request := *rule
+	request.Id = 0
+	body, _ := json.Marshal(request)
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/pricing-rules", nil, nil, string(body))
+	mockPricingRuleService := new(handler.MockPricingRuleService)
+	mockPricingRuleService.On("CreatePricingRule", request).Return(rule, nil)
+	handler := handler.NewPricingRuleHandler(mockPricingRuleService)
+
+	handler.HandleCreate(ctx)
+
+	createdRule := new(entities.PricingRule)
+	json.Unmarshal(recorder.Body.Bytes(), createdRule)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "/pricing-rules/3", recorder.Header().Get("Location"))
+	assert.Equal(t, rule, createdRule)
+}
+
+func Test_PricingRuleHandleUpdate_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	rule := givenPricingRule()
+	body, _ := json.Marshal(rule)
+	expectedError := errors.NewNotFoundError("pricing rule not found")
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/pricing-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "3"}}, nil, string(body))
+	mockPricingRuleService := new(handler.MockPricingRuleService)
+	mockPricingRuleService.On("UpdatePricingRule", int64(3), *rule).Return(nil, expectedError)
+	handler := handler.NewPricingRuleHandler(mockPricingRuleService)
+
+	handler.HandleUpdate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_PricingRuleHandleDelete_WhenProcessIsExecutedCorrectly_ThenReturnNoContent(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/pricing-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "3"}}, nil, "")
+	mockPricingRuleService := new(handler.MockPricingRuleService)
+	mockPricingRuleService.On("DeletePricingRule", int64(3)).Return(nil)
+	handler := handler.NewPricingRuleHandler(mockPricingRuleService)
+
+	handler.HandleDelete(ctx)
+	ctx.Writer.WriteHeaderNow()
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	mockPricingRuleService.AssertExpectations(t)
+}
+
+func givenPricingRule() *entities.PricingRule {
+	beerID := int64(1)
+
+	return &entities.PricingRule{
+		Id:          3,
+		BeerId:      &beerID,
+		Name:        "volume",
+		Type:        entities.PercentagePricingRule,
+		MinQuantity: 12,
+		Percentage:  decimal.NewFromInt(5),
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type TaxRuleService interface {
+	ListTaxRules() ([]entities.TaxRule, *errors.RestError)
+	GetTaxRuleByID(ruleID int64) (*entities.TaxRule, *errors.RestError)
+	CreateTaxRule(rule entities.TaxRule) (*entities.TaxRule, *errors.RestError)
+	UpdateTaxRule(ruleID int64, rule entities.TaxRule) (*entities.TaxRule, *errors.RestError)
+	DeleteTaxRule(ruleID int64) *errors.RestError
+}
+
+type taxRuleHandler struct {
+	taxRuleService TaxRuleService
+}
+
+func NewTaxRuleHandler(taxRuleService TaxRuleService) *taxRuleHandler {
+	return &taxRuleHandler{
+		taxRuleService: taxRuleService,
+	}
+}
+
+func (h *taxRuleHandler) HandleList(c *gin.Context) {
+	rules, restErr := h.taxRuleService.ListTaxRules()
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *taxRuleHandler) HandleGetByID(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.taxRuleService.GetTaxRuleByID(ruleID)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *taxRuleHandler) HandleCreate(c *gin.Context) {
+	var request entities.TaxRule
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.taxRuleService.CreateTaxRule(request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/tax-rules/%d", rule.Id))
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *taxRuleHandler) HandleUpdate(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	var request entities.TaxRule
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to bind request body: %s", err))
+		restErr := errors.NewBadRequestError("invalid json body")
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	rule, restErr := h.taxRuleService.UpdateTaxRule(ruleID, request)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *taxRuleHandler) HandleDelete(c *gin.Context) {
+	ruleID, restErr := parseRuleID(c)
+	if restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	if restErr := h.taxRuleService.DeleteTaxRule(ruleID); restErr != nil {
+		c.JSON(restErr.Status, restErr)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/handler"
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TaxRuleHandleList_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewInternalServerError("some error")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/tax-rules", nil, nil, "")
+	mockTaxRuleService := new(handler.MockTaxRuleService)
+	mockTaxRuleService.On("ListTaxRules").Return(nil, expectedError)
+	handler := handler.NewTaxRuleHandler(mockTaxRuleService)
+
+	handler.HandleList(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_TaxRuleHandleList_WhenProcessIsExecutedCorrectly_ThenReturnRules(t *testing.T) {
+	expectedRules := []entities.TaxRule{*givenTaxRule()}
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/tax-rules", nil, nil, "")
+	mockTaxRuleService := new(handler.MockTaxRuleService)
+	mockTaxRuleService.On("ListTaxRules").Return(expectedRules, nil)
+	handler := handler.NewTaxRuleHandler(mockTaxRuleService)
+
+	handler.HandleList(ctx)
+
+	var rules []entities.TaxRule
+	json.Unmarshal(recorder.Body.Bytes(), &rules)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expectedRules, rules)
+}
+
+func Test_TaxRuleHandleGetByID_WhenParamRuleIDIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("id should be a number")
+	ctx, recorder := givenContextAndRecorder(http.MethodGet, "/tax-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "abc"}}, nil, "")
+	handler := handler.NewTaxRuleHandler(nil)
+
+	handler.HandleGetByID(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_TaxRuleHandleCreate_WhenBodyIsInvalid_ThenReturnErrorAndStatusCode(t *testing.T) {
+	expectedError := errors.NewBadRequestError("invalid json body")
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/tax-rules", nil, nil, "{")
+	handler := handler.NewTaxRuleHandler(nil)
+
+	handler.HandleCreate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_TaxRuleHandleCreate_WhenProcessIsExecutedCorrectly_ThenReturnRuleAndLocation(t *testing.T) {
+	rule := givenTaxRule()
+	request := *rule
+	request.Id = 0
+	body, _ := json.Marshal(request)
+	ctx, recorder := givenContextAndRecorder(http.MethodPost, "/tax-rules", nil, nil, string(body))
+	mockTaxRuleService := new(handler.MockTaxRuleService)
+	mockTaxRuleService.On("CreateTaxRule", request).Return(rule, nil)
+	handler := handler.NewTaxRuleHandler(mockTaxRuleService)
+
+	handler.HandleCreate(ctx)
+
+	createdRule := new(entities.TaxRule)
+	json.Unmarshal(recorder.Body.Bytes(), createdRule)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Equal(t, "/tax-rules/3", recorder.Header().Get("Location"))
+	assert.Equal(t, rule, createdRule)
+}
+
+func Test_TaxRuleHandleUpdate_WhenServiceFail_ThenReturnErrorAndStatusCode(t *testing.T) {
+	rule := givenTaxRule()
+	body, _ := json.Marshal(rule)
+	expectedError := errors.NewNotFoundError("tax rule not found")
+	ctx, recorder := givenContextAndRecorder(http.MethodPut, "/tax-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "3"}}, nil, string(body))
+	mockTaxRuleService := new(handler.MockTaxRuleService)
+	mockTaxRuleService.On("UpdateTaxRule", int64(3), *rule).Return(nil, expectedError)
+	handler := handler.NewTaxRuleHandler(mockTaxRuleService)
+
+	handler.HandleUpdate(ctx)
+
+	assert.Equal(t, expectedError.Status, recorder.Code)
+	assert.Equal(t, expectedError, getRestError(recorder.Body.Bytes()))
+}
+
+func Test_TaxRuleHandleDelete_WhenProcessIsExecutedCorrectly_ThenReturnNoContent(t *testing.T) {
+	ctx, recorder := givenContextAndRecorder(http.MethodDelete, "/tax-rules/:rule_id",
+		[]gin.Param{{Key: "rule_id", Value: "3"}}, nil, "")
+	mockTaxRuleService := new(handler.MockTaxRuleService)
+	mockTaxRuleService.On("DeleteTaxRule", int64(3)).Return(nil)
+	handler := handler.NewTaxRuleHandler(mockTaxRuleService)
+
+	handler.HandleDelete(ctx)
+	ctx.Writer.WriteHeaderNow()
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	mockTaxRuleService.AssertExpectations(t)
+}
+
+func givenTaxRule() *entities.TaxRule {
+	return &entities.TaxRule{
+		Id:         3,
+		Country:    "DE",
+		Category:   entities.GeneralTaxCategory,
+		Name:       "VAT",
+		Type:       entities.PercentageTax,
+		Percentage: decimal.NewFromInt(19),
+		Amount:     decimal.NewFromInt(0),
+	}
+}
@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+)
+
+type cachedRate struct {
+	rate      *money.ExchangeRate
+	expiresAt time.Time
+}
+
+// rateCall is an in-flight upstream request shared by every caller asking for
+// the same currency pair.
+type rateCall struct {
+	done     chan struct{}
+	rate     *money.ExchangeRate
+	err      *errors.RestError
+	finished bool
+}
+
+// CachedCurrencyConverterClient caches the exchange rate of each currency pair
+// for a fixed TTL in front of another ExchangeRateProvider. Concurrent misses
+// for the same pair are collapsed into a single upstream request.
+type CachedCurrencyConverterClient struct {
+	provider ExchangeRateProvider
+	ttl      time.Duration
+
+	mutex sync.Mutex
+	rates map[string]cachedRate
+	calls map[string]*rateCall
+
+	hits   uint64
+	misses uint64
+}
+
+func NewCachedCurrencyConverterClient(provider ExchangeRateProvider, ttl time.Duration) *CachedCurrencyConverterClient {
+	return &CachedCurrencyConverterClient{
+		provider: provider,
+		ttl:      ttl,
+		rates:    make(map[string]cachedRate),
+		calls:    make(map[string]*rateCall),
+	}
+}
+
+func (c *CachedCurrencyConverterClient) GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
+	pair := fmt.Sprintf("%s-%s", oldCurrency, newCurrency)
+
+	c.mutex.Lock()
+	if cached, ok := c.rates[pair]; ok && time.Now().Before(cached.expiresAt) {
+		c.mutex.Unlock()
+		hits := atomic.AddUint64(&c.hits, 1)
+		logger.Log.Debug(fmt.Sprintf("exchange rate cache hit for %s, hits: %d, misses: %d",
+			pair, hits, atomic.LoadUint64(&c.misses)))
+
+		return cached.rate, nil
+	}
+
+	if call, ok := c.calls[pair]; ok {
+		c.mutex.Unlock()
+		<-call.done
+
+		return call.rate, call.err
+	}
+
+	call := &rateCall{done: make(chan struct{})}
+	c.calls[pair] = call
+	c.mutex.Unlock()
+	defer c.finishCall(pair, call)
+
+	misses := atomic.AddUint64(&c.misses, 1)
+	logger.Log.Debug(fmt.Sprintf("exchange rate cache miss for %s, hits: %d, misses: %d",
+		pair, atomic.LoadUint64(&c.hits), misses))
+
+	call.rate, call.err = c.provider.GetExchangeRate(oldCurrency, newCurrency)
+	call.finished = true
+
+	return call.rate, call.err
+}
+
+// finishCall caches the rate of a successful call and releases its waiters. It
+// runs deferred, so a panicking provider still releases them, with an error
+// since the call never got a rate.
+func (c *CachedCurrencyConverterClient) finishCall(pair string, call *rateCall) {
+	c.mutex.Lock()
+	if call.finished && call.err == nil {
+		c.rates[pair] = cachedRate{rate: call.rate, expiresAt: time.Now().Add(c.ttl)}
+	}
+	delete(c.calls, pair)
+	c.mutex.Unlock()
+
+	if !call.finished {
+		call.rate = nil
+		call.err = errors.NewInternalServerError(fmt.Sprintf("exchange rate request for %s did not finish", pair))
+	}
+	close(call.done)
+}
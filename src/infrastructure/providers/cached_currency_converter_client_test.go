@@ -0,0 +1,127 @@
+package providers_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const cacheTTL = time.Duration(50) * time.Millisecond
+
+func Test_CachedGetExchangeRate_WhenRateIsCached_ThenDoNotCallProvider(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00026")
+	mockProvider := new(providers.MockExchangeRateProvider)
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(expectedRate, nil).Once()
+	client := providers.NewCachedCurrencyConverterClient(mockProvider, cacheTTL)
+
+	client.GetExchangeRate(oldCurrency, newCurrency)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Equal(t, expectedRate, rate)
+	assert.Nil(t, err)
+	mockProvider.AssertNumberOfCalls(t, "GetExchangeRate", 1)
+}
+
+func Test_CachedGetExchangeRate_WhenRateExpires_ThenCallProviderAgain(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00026")
+	mockProvider := new(providers.MockExchangeRateProvider)
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(expectedRate, nil)
+	client := providers.NewCachedCurrencyConverterClient(mockProvider, cacheTTL)
+
+	client.GetExchangeRate(oldCurrency, newCurrency)
+	time.Sleep(2 * cacheTTL)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Equal(t, expectedRate, rate)
+	assert.Nil(t, err)
+	mockProvider.AssertNumberOfCalls(t, "GetExchangeRate", 2)
+}
+
+func Test_CachedGetExchangeRate_WhenProviderFail_ThenDoNotCacheError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	mockProvider := new(providers.MockExchangeRateProvider)
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(nil, expectedError)
+	client := providers.NewCachedCurrencyConverterClient(mockProvider, cacheTTL)
+
+	client.GetExchangeRate(oldCurrency, newCurrency)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+	mockProvider.AssertNumberOfCalls(t, "GetExchangeRate", 2)
+}
+
+func Test_CachedGetExchangeRate_WhenPairIsRequestedConcurrently_ThenCallProviderOnce(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00026")
+	mockProvider := new(providers.MockExchangeRateProvider)
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).
+		Run(func(_ mock.Arguments) { time.Sleep(cacheTTL / 2) }).
+		Return(expectedRate, nil)
+	client := providers.NewCachedCurrencyConverterClient(mockProvider, cacheTTL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+			assert.Equal(t, expectedRate, rate)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	mockProvider.AssertNumberOfCalls(t, "GetExchangeRate", 1)
+}
+
+func Test_CachedGetExchangeRate_WhenProviderPanics_ThenReleaseWaitersAndLaterCallers(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00026")
+	expectedError := errors.NewInternalServerError("exchange rate request for COP-USD did not finish")
+	release := make(chan struct{})
+	mockProvider := new(providers.MockExchangeRateProvider)
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).
+		Run(func(_ mock.Arguments) {
+			<-release
+			panic("provider failed")
+		}).
+		Return(nil, nil).Once()
+	mockProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(expectedRate, nil).Once()
+	client := providers.NewCachedCurrencyConverterClient(mockProvider, cacheTTL)
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() { panicked <- recover() }()
+		client.GetExchangeRate(oldCurrency, newCurrency)
+	}()
+	waiterErr := make(chan *errors.RestError)
+	go func() {
+		time.Sleep(cacheTTL / 5)
+		_, err := client.GetExchangeRate(oldCurrency, newCurrency)
+		waiterErr <- err
+	}()
+	time.Sleep(cacheTTL / 2)
+	close(release)
+
+	assert.Equal(t, "provider failed", <-panicked)
+	assert.Equal(t, expectedError, <-waiterErr)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+	assert.Equal(t, expectedRate, rate)
+	assert.Nil(t, err)
+	mockProvider.AssertNumberOfCalls(t, "GetExchangeRate", 2)
+}
+
+func givenExchangeRate(rate string) *money.ExchangeRate {
+	return &money.ExchangeRate{
+		From:     oldCurrency,
+		To:       newCurrency,
+		Rate:     decimal.RequireFromString(rate),
+		Provider: providers.RestProviderName,
+	}
+}
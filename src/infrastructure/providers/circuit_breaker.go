@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"sync"
+	"time"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker fails fast once FailureThreshold consecutive upstream errors
+// are recorded. After OpenTimeout a single probe request is let through: if it
+// succeeds the circuit closes again, otherwise it stays open for another
+// OpenTimeout.
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+
+	mutex    sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+// Allow reports whether a request may be sent upstream. A nil CircuitBreaker
+// always allows it.
+func (b *CircuitBreaker) Allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) RecordSuccess() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.state = circuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) RecordFailure() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.failureThreshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
@@ -0,0 +1,61 @@
+package providers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CircuitBreaker_WhenFailuresReachThreshold_ThenOpen(t *testing.T) {
+	circuitBreaker := providers.NewCircuitBreaker(2, time.Minute)
+
+	assert.True(t, circuitBreaker.Allow())
+	circuitBreaker.RecordFailure()
+	assert.True(t, circuitBreaker.Allow())
+	circuitBreaker.RecordFailure()
+
+	assert.False(t, circuitBreaker.Allow())
+}
+
+func Test_CircuitBreaker_WhenSuccessIsRecorded_ThenResetFailures(t *testing.T) {
+	circuitBreaker := providers.NewCircuitBreaker(2, time.Minute)
+
+	circuitBreaker.RecordFailure()
+	circuitBreaker.RecordSuccess()
+	circuitBreaker.RecordFailure()
+
+	assert.True(t, circuitBreaker.Allow())
+}
+
+func Test_CircuitBreaker_WhenOpenTimeoutElapses_ThenAllowSingleProbe(t *testing.T) {
+	circuitBreaker := providers.NewCircuitBreaker(1, 10*time.Millisecond)
+	circuitBreaker.RecordFailure()
+
+	time.Sleep(20 * time.Millisecond)
+
+	assert.True(t, circuitBreaker.Allow())
+	assert.False(t, circuitBreaker.Allow())
+}
+
+func Test_CircuitBreaker_WhenProbeFails_ThenOpenAgain(t *testing.T) {
+	circuitBreaker := providers.NewCircuitBreaker(3, 10*time.Millisecond)
+	circuitBreaker.RecordFailure()
+	circuitBreaker.RecordFailure()
+	circuitBreaker.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	assert.True(t, circuitBreaker.Allow())
+	circuitBreaker.RecordFailure()
+
+	assert.False(t, circuitBreaker.Allow())
+}
+
+func Test_CircuitBreaker_WhenItIsNil_ThenAlwaysAllow(t *testing.T) {
+	var circuitBreaker *providers.CircuitBreaker
+
+	circuitBreaker.RecordFailure()
+
+	assert.True(t, circuitBreaker.Allow())
+}
@@ -7,40 +7,73 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/dleonsal/beers-api/src/core/domain/money"
 	"github.com/dleonsal/beers-api/src/errors"
 	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/shopspring/decimal"
 )
 
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+const (
+	// exchangeRatePrecision is the number of decimal places kept when a rate
+	// has to be derived by division.
+	exchangeRatePrecision = 10
+
+	RestProviderName = "rapidapi"
+)
+
+// ExchangeRateProvider returns how many units of the new currency one unit of
+// the old currency is worth.
+type ExchangeRateProvider interface {
+	GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError)
+}
+
 type CurrencyConverterRestClient struct {
 	httpClient     HTTPClient
 	baseURL        string
 	requestTimeout time.Duration
 	xAPIKey        string
+	retryPolicy    RetryPolicy
+	circuitBreaker *CircuitBreaker
+}
+
+// upstreamFailure describes why an attempt against the upstream API failed.
+type upstreamFailure struct {
+	retryable     bool
+	retryAfter    time.Duration
+	hasRetryAfter bool
 }
 
-func NewCurrencyConverterRestClient(httpClient HTTPClient, baseURL string, requestTimeout time.Duration, xAPIkey string) *CurrencyConverterRestClient {
+// NewCurrencyConverterRestClient builds a client that retries 429, 5xx and
+// network errors according to retryPolicy. circuitBreaker may be nil to
+// disable it.
+func NewCurrencyConverterRestClient(
+	httpClient HTTPClient,
+	baseURL string,
+	requestTimeout time.Duration,
+	xAPIkey string,
+	retryPolicy RetryPolicy,
+	circuitBreaker *CircuitBreaker,
+) *CurrencyConverterRestClient {
 	return &CurrencyConverterRestClient{
 		httpClient:     httpClient,
 		baseURL:        baseURL,
 		requestTimeout: requestTimeout,
 		xAPIKey:        xAPIkey,
+		retryPolicy:    retryPolicy,
+		circuitBreaker: circuitBreaker,
 	}
 }
 
-func (c *CurrencyConverterRestClient) ConvertValueToNewCurrency(oldCurrency, newCurrency string, value float64) (float64, *errors.RestError) {
+func (c *CurrencyConverterRestClient) GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
 	url := fmt.Sprintf("%s/exchange", c.baseURL)
-	reqCtx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("error trying to create request: %s", err))
-		return 0, errors.NewInternalServerError("error trying to convert from one currency to another")
-
+		return nil, errors.NewInternalServerError("error trying to convert from one currency to another")
 	}
 
 	req.Header.Add("x-rapidapi-key", c.xAPIKey)
@@ -49,24 +82,81 @@ func (c *CurrencyConverterRestClient) ConvertValueToNewCurrency(oldCurrency, new
 	q.Add("to", newCurrency)
 	req.URL.RawQuery = q.Encode()
 
-	response, err := c.httpClient.Do(req)
+	attempts := c.retryPolicy.attempts()
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if !c.circuitBreaker.Allow() {
+			logger.Log.Error("error trying to convert from one currency to another, circuit breaker is open")
+			break
+		}
+
+		rate, failure := c.doExchangeRequest(req, oldCurrency, newCurrency)
+		if failure == nil {
+			c.circuitBreaker.RecordSuccess()
+			return rate, nil
+		}
+
+		if !failure.retryable {
+			// The upstream answered, so it is not counted against the circuit breaker.
+			c.circuitBreaker.RecordSuccess()
+			break
+		}
+		c.circuitBreaker.RecordFailure()
+
+		if attempt == attempts {
+			break
+		}
+
+		wait := c.retryPolicy.backoff(attempt - 1)
+		if failure.hasRetryAfter {
+			if failure.retryAfter > c.retryPolicy.MaxBackoff {
+				logger.Log.Error(fmt.Sprintf("upstream asked to retry after %s, longer than the max backoff %s",
+					failure.retryAfter, c.retryPolicy.MaxBackoff))
+				break
+			}
+			wait = failure.retryAfter
+		}
+
+		logger.Log.Info(fmt.Sprintf("retrying exchange rate request in %s, attempt %d of %d", wait, attempt+1, attempts))
+		time.Sleep(wait)
+	}
+
+	return nil, errors.NewInternalServerError("error trying to convert from one currency to another")
+}
+
+func (c *CurrencyConverterRestClient) doExchangeRequest(req *http.Request, oldCurrency, newCurrency string) (*money.ExchangeRate, *upstreamFailure) {
+	reqCtx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
+	defer cancel()
+
+	response, err := c.httpClient.Do(req.WithContext(reqCtx))
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("error trying to execute request: %s", err))
-		return 0, errors.NewInternalServerError("error trying to convert from one currency to another")
+		return nil, &upstreamFailure{retryable: true}
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		logger.Log.Error(fmt.Sprintf("error trying to convert from one currency to another, response code %d", response.StatusCode))
-		return 0, errors.NewInternalServerError("error trying to convert from one currency to another")
+		retryAfter, hasRetryAfter := parseRetryAfter(response.Header.Get("Retry-After"))
+
+		return nil, &upstreamFailure{
+			retryable:     isRetryableStatus(response.StatusCode),
+			retryAfter:    retryAfter,
+			hasRetryAfter: hasRetryAfter,
+		}
 	}
 
-	var result float64
-	err = json.NewDecoder(response.Body).Decode(&result)
+	var rate decimal.Decimal
+	err = json.NewDecoder(response.Body).Decode(&rate)
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("error trying to decode response body: %s", err))
-		return 0, errors.NewInternalServerError("error trying to convert from one currency to another")
+		return nil, &upstreamFailure{}
 	}
 
-	return result * value, nil
+	return &money.ExchangeRate{
+		From:      oldCurrency,
+		To:        newCurrency,
+		Rate:      rate,
+		Provider:  RestProviderName,
+		Timestamp: time.Now().UTC(),
+	}, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/dleonsal/beers-api/src/errors"
 	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -18,33 +19,32 @@ const (
 	xAPIkey        = "123456"
 	oldCurrency    = "COP"
 	newCurrency    = "USD"
-	value          = 10
 )
 
-func Test_ConvertValueToNewCurrency_WhenCreateRequestFail_ThenReturnError(t *testing.T) {
+func Test_GetExchangeRate_WhenCreateRequestFail_ThenReturnError(t *testing.T) {
 	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
-	client := providers.NewCurrencyConverterRestClient(nil, "%invalid url%", requestTimeout, xAPIkey)
+	client := providers.NewCurrencyConverterRestClient(nil, "%invalid url%", requestTimeout, xAPIkey, providers.RetryPolicy{}, nil)
 
-	totalPrice, err := client.ConvertValueToNewCurrency(oldCurrency, newCurrency, value)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
 
-	assert.Equal(t, totalPrice, 0.0)
+	assert.Nil(t, rate)
 	assert.Equal(t, expectedError, err)
 }
 
-func Test_ConvertValueToNewCurrency_WhenDoRequestFail_ThenReturnError(t *testing.T) {
+func Test_GetExchangeRate_WhenDoRequestFail_ThenReturnError(t *testing.T) {
 	error := genericerros.New("some error")
 	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
 	mockHTTPClient := new(providers.MockHTTPClient)
 	mockHTTPClient.On("Do", mock.Anything).Return(nil, error)
-	client := providers.NewCurrencyConverterRestClient(mockHTTPClient, baseURL, requestTimeout, xAPIkey)
+	client := providers.NewCurrencyConverterRestClient(mockHTTPClient, baseURL, requestTimeout, xAPIkey, providers.RetryPolicy{}, nil)
 
-	totalPrice, err := client.ConvertValueToNewCurrency(oldCurrency, newCurrency, value)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
 
-	assert.Equal(t, totalPrice, 0.0)
+	assert.Nil(t, rate)
 	assert.Equal(t, expectedError, err)
 }
 
-func Test_ConvertValueToNewCurrency_WhenResponseWithCodeDifferentTo200_ThenReturnError(t *testing.T) {
+func Test_GetExchangeRate_WhenResponseWithCodeDifferentTo200_ThenReturnError(t *testing.T) {
 	expectedResponse := `{"message":"some bad request","error":"bad_request","status":400}`
 	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
 	server := providers.NewMockServerConfig(
@@ -55,15 +55,15 @@ func Test_ConvertValueToNewCurrency_WhenResponseWithCodeDifferentTo200_ThenRetur
 	).CreateMockServer()
 	defer server.Close()
 
-	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey)
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, providers.RetryPolicy{}, nil)
 
-	totalPrice, err := client.ConvertValueToNewCurrency(oldCurrency, newCurrency, value)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
 
-	assert.Equal(t, totalPrice, 0.0)
+	assert.Nil(t, rate)
 	assert.Equal(t, expectedError, err)
 }
 
-func Test_ConvertValueToNewCurrency_WhenDecodeResponseFail_ThenReturnError(t *testing.T) {
+func Test_GetExchangeRate_WhenDecodeResponseFail_ThenReturnError(t *testing.T) {
 	expectedResponse := `{,}`
 	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
 	server := providers.NewMockServerConfig(
@@ -74,17 +74,16 @@ func Test_ConvertValueToNewCurrency_WhenDecodeResponseFail_ThenReturnError(t *te
 	).CreateMockServer()
 	defer server.Close()
 
-	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey)
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, providers.RetryPolicy{}, nil)
 
-	totalPrice, err := client.ConvertValueToNewCurrency(oldCurrency, newCurrency, value)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
 
-	assert.Equal(t, totalPrice, 0.0)
+	assert.Nil(t, rate)
 	assert.Equal(t, expectedError, err)
 }
 
-func Test_ConvertValueToNewCurrency_WhenProcessIsExecutedSuccessfully_ThenReturnResult(t *testing.T) {
+func Test_GetExchangeRate_WhenProcessIsExecutedSuccessfully_ThenReturnRate(t *testing.T) {
 	expectedResponse := `10.0`
-	expectedTotalPrice := 100.0
 	server := providers.NewMockServerConfig(
 		http.StatusOK,
 		"application/json",
@@ -93,10 +92,173 @@ func Test_ConvertValueToNewCurrency_WhenProcessIsExecutedSuccessfully_ThenReturn
 	).CreateMockServer()
 	defer server.Close()
 
-	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey)
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, providers.RetryPolicy{}, nil)
 
-	totalPrice, err := client.ConvertValueToNewCurrency(oldCurrency, newCurrency, value)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
 
-	assert.Equal(t, expectedTotalPrice, totalPrice)
 	assert.Nil(t, err)
+	assert.Equal(t, oldCurrency, rate.From)
+	assert.Equal(t, newCurrency, rate.To)
+	assert.True(t, decimal.NewFromInt(10).Equal(rate.Rate))
+	assert.Equal(t, providers.RestProviderName, rate.Provider)
+	assert.False(t, rate.Timestamp.IsZero())
+}
+
+func Test_GetExchangeRate_WhenUpstreamFailsWithRetryableCode_ThenRetryUntilSuccess(t *testing.T) {
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusServiceUnavailable, "application/json", nil, ""),
+		providers.NewMockServerConfig(http.StatusTooManyRequests, "application/json", nil, ""),
+		providers.NewMockServerConfig(http.StatusOK, "application/json", nil, `10.0`),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, givenRetryPolicy(3), nil)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, err)
+	assert.True(t, decimal.NewFromInt(10).Equal(rate.Rate))
+	assert.Equal(t, 3, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenEveryAttemptFails_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusBadGateway, "application/json", nil, ""),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, givenRetryPolicy(3), nil)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 3, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenResponseCodeIsNotRetryable_ThenDoNotRetry(t *testing.T) {
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusBadRequest, "application/json", nil, ""),
+		providers.NewMockServerConfig(http.StatusOK, "application/json", nil, `10.0`),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, givenRetryPolicy(3), nil)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenDoRequestFail_ThenRetry(t *testing.T) {
+	mockHTTPClient := new(providers.MockHTTPClient)
+	mockHTTPClient.On("Do", mock.Anything).Return(nil, genericerros.New("some error"))
+	client := providers.NewCurrencyConverterRestClient(mockHTTPClient, baseURL, requestTimeout, xAPIkey, givenRetryPolicy(2), nil)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.NotNil(t, err)
+	mockHTTPClient.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func Test_GetExchangeRate_WhenRetryAfterIsLongerThanMaxBackoff_ThenDoNotRetry(t *testing.T) {
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusTooManyRequests, "application/json", map[string]string{"Retry-After": "120"}, ""),
+		providers.NewMockServerConfig(http.StatusOK, "application/json", nil, `10.0`),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, givenRetryPolicy(3), nil)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenRetryAfterIsPresent_ThenWaitBeforeRetrying(t *testing.T) {
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusServiceUnavailable, "application/json", map[string]string{"Retry-After": "1"}, ""),
+		providers.NewMockServerConfig(http.StatusOK, "application/json", nil, `10.0`),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	retryPolicy := providers.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, retryPolicy, nil)
+
+	start := time.Now()
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, rate)
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, 2, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenCircuitBreakerIsOpen_ThenFailFast(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusInternalServerError, "application/json", nil, ""),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	circuitBreaker := providers.NewCircuitBreaker(2, time.Minute)
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, givenRetryPolicy(3), circuitBreaker)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 2, sequence.Requests())
+
+	rate, err = client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 2, sequence.Requests())
+}
+
+func Test_GetExchangeRate_WhenHalfOpenProbeSucceeds_ThenCloseCircuit(t *testing.T) {
+	sequence := providers.NewMockServerSequence(
+		providers.NewMockServerConfig(http.StatusInternalServerError, "application/json", nil, ""),
+		providers.NewMockServerConfig(http.StatusOK, "application/json", nil, `10.0`),
+	)
+	server := sequence.CreateMockServer()
+	defer server.Close()
+
+	circuitBreaker := providers.NewCircuitBreaker(1, 20*time.Millisecond)
+	client := providers.NewCurrencyConverterRestClient(&http.Client{}, server.URL, requestTimeout, xAPIkey, providers.RetryPolicy{}, circuitBreaker)
+
+	_, err := client.GetExchangeRate(oldCurrency, newCurrency)
+	assert.NotNil(t, err)
+	_, err = client.GetExchangeRate(oldCurrency, newCurrency)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, sequence.Requests())
+
+	time.Sleep(30 * time.Millisecond)
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, rate)
+	assert.Equal(t, 2, sequence.Requests())
+	assert.True(t, circuitBreaker.Allow())
+}
+
+func givenRetryPolicy(maxAttempts int) providers.RetryPolicy {
+	return providers.RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
 }
@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ECBProviderName = "ecb"
+
+	ecbBaseCurrency = "EUR"
+	ecbDateLayout   = "2006-01-02"
+)
+
+type ecbEnvelope struct {
+	Cube struct {
+		Cube struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// ECBCurrencyConverterClient reads the euro reference rates from an ECB-style
+// daily XML feed and derives cross rates through the euro.
+type ECBCurrencyConverterClient struct {
+	httpClient     HTTPClient
+	feedURL        string
+	requestTimeout time.Duration
+}
+
+func NewECBCurrencyConverterClient(httpClient HTTPClient, feedURL string, requestTimeout time.Duration) *ECBCurrencyConverterClient {
+	return &ECBCurrencyConverterClient{
+		httpClient:     httpClient,
+		feedURL:        feedURL,
+		requestTimeout: requestTimeout,
+	}
+}
+
+func (c *ECBCurrencyConverterClient) GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
+	rates, date, err := c.getDailyRates()
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := rates.crossRate(oldCurrency, newCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &money.ExchangeRate{
+		From:      oldCurrency,
+		To:        newCurrency,
+		Rate:      rate,
+		Provider:  ECBProviderName,
+		Timestamp: date,
+	}, nil
+}
+
+func (c *ECBCurrencyConverterClient) getDailyRates() (StaticRates, time.Time, *errors.RestError) {
+	reqCtx, cancel := context.WithTimeout(context.Background(), c.requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, c.feedURL, nil)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to create request: %s", err))
+		return StaticRates{}, time.Time{}, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute request: %s", err))
+		return StaticRates{}, time.Time{}, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		logger.Log.Error(fmt.Sprintf("error trying to get the daily rates feed, response code %d", response.StatusCode))
+		return StaticRates{}, time.Time{}, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(response.Body).Decode(&envelope); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to decode the daily rates feed: %s", err))
+		return StaticRates{}, time.Time{}, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	date, err := time.Parse(ecbDateLayout, envelope.Cube.Cube.Time)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to parse the daily rates feed date: %s", err))
+		return StaticRates{}, time.Time{}, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	rates := StaticRates{
+		Base:  ecbBaseCurrency,
+		Rates: make(map[string]decimal.Decimal, len(envelope.Cube.Cube.Rates)),
+	}
+	for _, cube := range envelope.Cube.Cube.Rates {
+		rate, err := decimal.NewFromString(cube.Rate)
+		if err != nil || rate.Sign() <= 0 {
+			logger.Log.Error(fmt.Sprintf("invalid rate %q for currency %s in the daily rates feed", cube.Rate, cube.Currency))
+			continue
+		}
+		rates.Rates[cube.Currency] = rate
+	}
+
+	return rates.normalize(), date, nil
+}
@@ -0,0 +1,97 @@
+package providers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const ecbDailyFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2021-11-19">
+			<Cube currency="USD" rate="1.25"/>
+			<Cube currency="COP" rate="5000"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func Test_ECBGetExchangeRate_WhenResponseWithCodeDifferentTo200_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	server := providers.NewMockServerConfig(
+		http.StatusServiceUnavailable,
+		"application/xml",
+		nil,
+		"",
+	).CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewECBCurrencyConverterClient(&http.Client{}, server.URL, requestTimeout)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ECBGetExchangeRate_WhenDecodeResponseFail_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	server := providers.NewMockServerConfig(
+		http.StatusOK,
+		"application/xml",
+		nil,
+		"<Cube>",
+	).CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewECBCurrencyConverterClient(&http.Client{}, server.URL, requestTimeout)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ECBGetExchangeRate_WhenCurrencyIsNotInFeed_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	server := providers.NewMockServerConfig(
+		http.StatusOK,
+		"application/xml",
+		nil,
+		ecbDailyFeed,
+	).CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewECBCurrencyConverterClient(&http.Client{}, server.URL, requestTimeout)
+
+	rate, err := client.GetExchangeRate(oldCurrency, "JPY")
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ECBGetExchangeRate_WhenProcessIsExecutedSuccessfully_ThenReturnCrossRate(t *testing.T) {
+	expectedTimestamp := time.Date(2021, 11, 19, 0, 0, 0, 0, time.UTC)
+	server := providers.NewMockServerConfig(
+		http.StatusOK,
+		"application/xml",
+		nil,
+		ecbDailyFeed,
+	).CreateMockServer()
+	defer server.Close()
+
+	client := providers.NewECBCurrencyConverterClient(&http.Client{}, server.URL, requestTimeout)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, err)
+	assert.True(t, decimal.RequireFromString("0.00025").Equal(rate.Rate))
+	assert.Equal(t, providers.ECBProviderName, rate.Provider)
+	assert.Equal(t, expectedTimestamp, rate.Timestamp)
+}
@@ -0,0 +1,39 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+)
+
+// FailoverCurrencyConverterClient asks each provider in order and returns the
+// first rate it gets. Providers are expected to bound their own requests with
+// a timeout, so a slow provider shows up as an error and the next one is tried.
+type FailoverCurrencyConverterClient struct {
+	providers []ExchangeRateProvider
+}
+
+func NewFailoverCurrencyConverterClient(providers ...ExchangeRateProvider) *FailoverCurrencyConverterClient {
+	return &FailoverCurrencyConverterClient{
+		providers: providers,
+	}
+}
+
+func (c *FailoverCurrencyConverterClient) GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
+	lastErr := errors.NewInternalServerError("there is no exchange rate provider configured")
+
+	for i, provider := range c.providers {
+		rate, err := provider.GetExchangeRate(oldCurrency, newCurrency)
+		if err == nil {
+			return rate, nil
+		}
+
+		logger.Log.Error(fmt.Sprintf("exchange rate provider %d of %d failed for %s-%s: %s",
+			i+1, len(c.providers), oldCurrency, newCurrency, err.Message))
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,57 @@
+package providers_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FailoverGetExchangeRate_WhenFirstProviderWorks_ThenDoNotCallNextProvider(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00026")
+	firstProvider := new(providers.MockExchangeRateProvider)
+	firstProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(expectedRate, nil)
+	secondProvider := new(providers.MockExchangeRateProvider)
+	client := providers.NewFailoverCurrencyConverterClient(firstProvider, secondProvider)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Equal(t, expectedRate, rate)
+	assert.Nil(t, err)
+	secondProvider.AssertNotCalled(t, "GetExchangeRate", oldCurrency, newCurrency)
+}
+
+func Test_FailoverGetExchangeRate_WhenFirstProviderFail_ThenReturnRateFromNextProvider(t *testing.T) {
+	expectedRate := givenExchangeRate("0.00025")
+	expectedRate.Provider = providers.ECBProviderName
+	firstProvider := new(providers.MockExchangeRateProvider)
+	firstProvider.On("GetExchangeRate", oldCurrency, newCurrency).
+		Return(nil, errors.NewInternalServerError("error trying to convert from one currency to another"))
+	secondProvider := new(providers.MockExchangeRateProvider)
+	secondProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(expectedRate, nil)
+	client := providers.NewFailoverCurrencyConverterClient(firstProvider, secondProvider)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Equal(t, expectedRate, rate)
+	assert.Equal(t, providers.ECBProviderName, rate.Provider)
+	assert.Nil(t, err)
+	firstProvider.AssertExpectations(t)
+	secondProvider.AssertExpectations(t)
+}
+
+func Test_FailoverGetExchangeRate_WhenEveryProviderFail_ThenReturnLastError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	firstProvider := new(providers.MockExchangeRateProvider)
+	firstProvider.On("GetExchangeRate", oldCurrency, newCurrency).
+		Return(nil, errors.NewInternalServerError("some error"))
+	secondProvider := new(providers.MockExchangeRateProvider)
+	secondProvider.On("GetExchangeRate", oldCurrency, newCurrency).Return(nil, expectedError)
+	client := providers.NewFailoverCurrencyConverterClient(firstProvider, secondProvider)
+
+	rate, err := client.GetExchangeRate(oldCurrency, newCurrency)
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 )
 
 type MockServerConfig struct {
@@ -28,15 +29,44 @@ func NewMockServerConfig(
 }
 
 func (config MockServerConfig) CreateMockServer() *httptest.Server {
-	handler := func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", config.contentType)
-		w.WriteHeader(config.responseStatusCode)
-		fmt.Fprintln(w, config.response)
+	return httptest.NewServer(http.HandlerFunc(config.write))
+}
+
+func (config MockServerConfig) write(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", config.contentType)
+	for header, value := range config.headers {
+		w.Header().Set(header, value)
+	}
+
+	w.WriteHeader(config.responseStatusCode)
+	fmt.Fprintln(w, config.response)
+}
+
+// MockServerSequence answers each request with the next config of the
+// sequence, repeating the last one once the sequence is exhausted.
+type MockServerSequence struct {
+	configs  []*MockServerConfig
+	requests int64
+}
+
+func NewMockServerSequence(configs ...*MockServerConfig) *MockServerSequence {
+	return &MockServerSequence{configs: configs}
+}
 
-		for header, value := range config.headers {
-			w.Header().Set(header, value)
+func (sequence *MockServerSequence) CreateMockServer() *httptest.Server {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		index := int(atomic.AddInt64(&sequence.requests, 1)) - 1
+		if index >= len(sequence.configs) {
+			index = len(sequence.configs) - 1
 		}
+
+		sequence.configs[index].write(w, r)
 	}
 
 	return httptest.NewServer(http.HandlerFunc(handler))
 }
+
+// Requests returns how many requests the server has received.
+func (sequence *MockServerSequence) Requests() int {
+	return int(atomic.LoadInt64(&sequence.requests))
+}
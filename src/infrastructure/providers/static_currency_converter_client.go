@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/money"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v2"
+)
+
+const StaticProviderName = "static"
+
+// StaticRates is a rate table where every rate is the number of units of the
+// currency worth one unit of the base currency.
+type StaticRates struct {
+	Base  string
+	Rates map[string]decimal.Decimal
+}
+
+// StaticCurrencyConverterClient converts values with a fixed rate table, so the
+// service can run without access to an exchange rate API. Cross rates between
+// two non-base currencies are derived through the base currency.
+type StaticCurrencyConverterClient struct {
+	rates StaticRates
+}
+
+func NewStaticCurrencyConverterClient(rates StaticRates) *StaticCurrencyConverterClient {
+	return &StaticCurrencyConverterClient{
+		rates: rates.normalize(),
+	}
+}
+
+// normalize upper-cases every code and adds the base currency to the table.
+func (r StaticRates) normalize() StaticRates {
+	normalizedRates := make(map[string]decimal.Decimal, len(r.Rates)+1)
+	for currency, rate := range r.Rates {
+		normalizedRates[strings.ToUpper(currency)] = rate
+	}
+
+	base := strings.ToUpper(r.Base)
+	normalizedRates[base] = decimal.NewFromInt(1)
+
+	return StaticRates{
+		Base:  base,
+		Rates: normalizedRates,
+	}
+}
+
+// LoadStaticRates reads a rate table from a YAML or JSON file, chosen by its extension.
+func LoadStaticRates(path string) (StaticRates, error) {
+	var rates StaticRates
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return rates, err
+	}
+
+	var rawRates struct {
+		Base  string            `yaml:"Base"`
+		Rates map[string]string `yaml:"Rates"`
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var jsonRates struct {
+			Base  string                 `json:"Base"`
+			Rates map[string]json.Number `json:"Rates"`
+		}
+		err = json.Unmarshal(content, &jsonRates)
+		rawRates.Base = jsonRates.Base
+		rawRates.Rates = make(map[string]string, len(jsonRates.Rates))
+		for currency, rate := range jsonRates.Rates {
+			rawRates.Rates[currency] = rate.String()
+		}
+	} else {
+		err = yaml.Unmarshal(content, &rawRates)
+	}
+	if err != nil {
+		return rates, err
+	}
+
+	if len(strings.TrimSpace(rawRates.Base)) == 0 {
+		return rates, fmt.Errorf("static rates file %s must define a Base currency", path)
+	}
+
+	rates.Base = rawRates.Base
+	rates.Rates = make(map[string]decimal.Decimal, len(rawRates.Rates))
+	for currency, rawRate := range rawRates.Rates {
+		rate, err := decimal.NewFromString(rawRate)
+		if err != nil || rate.Sign() <= 0 {
+			return rates, fmt.Errorf("invalid rate %q for currency %s in %s", rawRate, currency, path)
+		}
+		rates.Rates[currency] = rate
+	}
+
+	return rates, nil
+}
+
+func (c *StaticCurrencyConverterClient) GetExchangeRate(oldCurrency, newCurrency string) (*money.ExchangeRate, *errors.RestError) {
+	rate, err := c.rates.crossRate(oldCurrency, newCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &money.ExchangeRate{
+		From:      oldCurrency,
+		To:        newCurrency,
+		Rate:      rate,
+		Provider:  StaticProviderName,
+		Timestamp: time.Now().UTC(),
+	}, nil
+}
+
+// crossRate derives the rate between two currencies through the base currency.
+func (r StaticRates) crossRate(oldCurrency, newCurrency string) (decimal.Decimal, *errors.RestError) {
+	oldRate, ok := r.Rates[oldCurrency]
+	if !ok {
+		logger.Log.Error(fmt.Sprintf("there is no %s rate for currency %s", r.Base, oldCurrency))
+		return decimal.Zero, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	newRate, ok := r.Rates[newCurrency]
+	if !ok {
+		logger.Log.Error(fmt.Sprintf("there is no %s rate for currency %s", r.Base, newCurrency))
+		return decimal.Zero, errors.NewInternalServerError("error trying to convert from one currency to another")
+	}
+
+	return newRate.DivRound(oldRate, exchangeRatePrecision), nil
+}
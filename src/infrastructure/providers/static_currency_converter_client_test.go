@@ -0,0 +1,79 @@
+package providers_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/providers"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LoadStaticRates_WhenFileIsYAML_ThenReturnRates(t *testing.T) {
+	rates, err := providers.LoadStaticRates("testdata/static_rates.yaml")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", rates.Base)
+	assert.True(t, decimal.NewFromInt(4000).Equal(rates.Rates["COP"]))
+	assert.True(t, decimal.RequireFromString("0.8").Equal(rates.Rates["EUR"]))
+}
+
+func Test_LoadStaticRates_WhenFileIsJSON_ThenReturnRates(t *testing.T) {
+	rates, err := providers.LoadStaticRates("testdata/static_rates.json")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", rates.Base)
+	assert.True(t, decimal.NewFromInt(4000).Equal(rates.Rates["COP"]))
+	assert.True(t, decimal.RequireFromString("0.8").Equal(rates.Rates["EUR"]))
+}
+
+func Test_LoadStaticRates_WhenBaseIsMissing_ThenReturnError(t *testing.T) {
+	_, err := providers.LoadStaticRates("testdata/static_rates_without_base.yaml")
+
+	assert.EqualError(t, err, "static rates file testdata/static_rates_without_base.yaml must define a Base currency")
+}
+
+func Test_LoadStaticRates_WhenFileDoesNotExist_ThenReturnError(t *testing.T) {
+	_, err := providers.LoadStaticRates("testdata/missing.yaml")
+
+	assert.NotNil(t, err)
+}
+
+func Test_StaticGetExchangeRate_WhenCurrencyIsUnknown_ThenReturnError(t *testing.T) {
+	expectedError := errors.NewInternalServerError("error trying to convert from one currency to another")
+	client := providers.NewStaticCurrencyConverterClient(givenStaticRates())
+
+	rate, err := client.GetExchangeRate("COP", "JPY")
+
+	assert.Nil(t, rate)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_StaticGetExchangeRate_WhenOldCurrencyIsBase_ThenReturnTableRate(t *testing.T) {
+	client := providers.NewStaticCurrencyConverterClient(givenStaticRates())
+
+	rate, err := client.GetExchangeRate("USD", "COP")
+
+	assert.Nil(t, err)
+	assert.True(t, decimal.NewFromInt(4000).Equal(rate.Rate))
+	assert.Equal(t, providers.StaticProviderName, rate.Provider)
+}
+
+func Test_StaticGetExchangeRate_WhenNoCurrencyIsBase_ThenReturnCrossRate(t *testing.T) {
+	client := providers.NewStaticCurrencyConverterClient(givenStaticRates())
+
+	rate, err := client.GetExchangeRate("COP", "EUR")
+
+	assert.Nil(t, err)
+	assert.True(t, decimal.RequireFromString("0.0002").Equal(rate.Rate))
+}
+
+func givenStaticRates() providers.StaticRates {
+	return providers.StaticRates{
+		Base: "usd",
+		Rates: map[string]decimal.Decimal{
+			"COP": decimal.NewFromInt(4000),
+			"eur": decimal.RequireFromString("0.8"),
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/repositorytest"
+)
+
+// The MySQL suite runs in the BEERS_TEST_MYSQL_DB schema of the server at
+// BEERS_TEST_MYSQL_HOST, created and dropped by the test. It is skipped when the
+// host is not set, and refuses to run when the schema already exists, so it
+// never touches a database in use.
+const (
+	mysqlTestHostEnv     = "BEERS_TEST_MYSQL_HOST"
+	mysqlTestUserEnv     = "BEERS_TEST_MYSQL_USER"
+	mysqlTestPasswordEnv = "BEERS_TEST_MYSQL_PASSWORD"
+	mysqlTestDBEnv       = "BEERS_TEST_MYSQL_DB"
+)
+
+var mysqlTestDBName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func Test_MemoryBeerRepository_ThenPassBeerRepositorySuite(t *testing.T) {
+	repositorytest.RunBeerRepositorySuite(t, func(t *testing.T) services.BeerRepository {
+		return repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	})
+}
+
+func Test_SQLiteBeerRepository_ThenPassBeerRepositorySuite(t *testing.T) {
+	repositorytest.RunBeerRepositorySuite(t, func(t *testing.T) services.BeerRepository {
+		return repository.NewSqlBeerRepository(givenSQLiteDB(t), db.SQLite)
+	})
+}
+
+func Test_MySqlBeerRepository_ThenPassBeerRepositorySuite(t *testing.T) {
+	client := givenMySqlTestDB(t)
+
+	repositorytest.RunBeerRepositorySuite(t, func(t *testing.T) services.BeerRepository {
+		if _, err := client.Exec("DELETE FROM beer;"); err != nil {
+			t.Fatal(err)
+		}
+
+		return repository.NewMySqlBeerRepository(client)
+	})
+}
+
+// givenMySqlTestDB creates the throwaway schema of the suite, with the
+// migrations applied, and drops it when the test ends.
+func givenMySqlTestDB(t *testing.T) *sql.DB {
+	config := &configs.DBConfig{
+		DriverName: configs.MySQLDriverName,
+		Host:       os.Getenv(mysqlTestHostEnv),
+		UserName:   os.Getenv(mysqlTestUserEnv),
+		Password:   os.Getenv(mysqlTestPasswordEnv),
+	}
+	if config.Host == "" {
+		t.Skipf("%s is not set", mysqlTestHostEnv)
+	}
+
+	dbName := os.Getenv(mysqlTestDBEnv)
+	if config.UserName == "" || !mysqlTestDBName.MatchString(dbName) {
+		t.Fatalf("%s and %s, made of letters, digits and underscores, must be set", mysqlTestUserEnv, mysqlTestDBEnv)
+	}
+
+	server, err := db.NewSqlDB(config, db.MySQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	var schemas int
+	if err := server.QueryRow("SELECT COUNT(*) FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?;", dbName).Scan(&schemas); err != nil {
+		t.Fatal(err)
+	}
+	if schemas > 0 {
+		t.Fatalf("schema %s already exists, the suite only runs in a schema it creates", dbName)
+	}
+
+	if _, err := server.Exec(fmt.Sprintf("CREATE DATABASE `%s`;", dbName)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cleanup, err := db.NewSqlDB(config, db.MySQL)
+		if err != nil {
+			t.Errorf("error trying to drop schema %s: %s", dbName, err)
+			return
+		}
+		defer cleanup.Close()
+		if _, err := cleanup.Exec(fmt.Sprintf("DROP DATABASE `%s`;", dbName)); err != nil {
+			t.Errorf("error trying to drop schema %s: %s", dbName, err)
+		}
+	})
+
+	config.DBName = dbName
+	client, err := db.NewSqlDB(config, db.MySQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	migrations, _ := db.EmbeddedMigrations(db.MySQL)
+	if _, err := db.NewMigrator(client, db.MySQL, migrations).Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
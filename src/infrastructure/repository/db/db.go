@@ -7,34 +7,18 @@ import (
 	"github.com/dleonsal/beers-api/src/configs"
 )
 
-const (
-	queryCreateBeerTable = `CREATE TABLE IF NOT EXISTS beer (
-			id bigint(20) NOT NULL,
-			name varchar(45) COLLATE utf8_spanish2_ci DEFAULT NULL,
-			brewery varchar(45) COLLATE utf8_spanish2_ci DEFAULT NULL,
-			country varchar(45) COLLATE utf8_spanish2_ci NOT NULL,
-			price decimal(10,2) DEFAULT NULL,
-			currency varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
-			PRIMARY KEY (id)
-			) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;`
-)
-
-func NewMySqlDB(config *configs.DBConfig) *sql.DB {
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8",
-		config.UserName,
-		config.Password,
-		config.Host,
-		config.DBName,
-	)
-
-	client, err := sql.Open(config.DriverName, dataSourceName)
+// NewSqlDB opens the database of the config with the driver of the dialect and
+// checks that it is reachable. Its schema is managed by the Migrator.
+func NewSqlDB(config *configs.DBConfig, dialect Dialect) (*sql.DB, error) {
+	client, err := sql.Open(dialect.DriverName(), dialect.DataSourceName(config))
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("error trying to open %s database: %s", dialect.DriverName(), err)
 	}
 
-	if _, err := client.Exec(queryCreateBeerTable); err != nil {
-		panic(err)
+	if err := client.Ping(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error trying to connect to %s database: %s", dialect.DriverName(), err)
 	}
 
-	return client
+	return client, nil
 }
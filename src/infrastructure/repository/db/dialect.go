@@ -0,0 +1,276 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect holds what changes between the SQL databases supported by the
+// repositories and the Migrator. Queries are written with ? placeholders and
+// rebound to the ones of the dialect.
+type Dialect interface {
+	// DriverName is the database/sql driver of the dialect, the same value as
+	// DBConfig.DriverName.
+	DriverName() string
+	DataSourceName(config *configs.DBConfig) string
+	Rebind(query string) string
+	IsDuplicateKey(err error) bool
+	// ReturnsInsertID tells whether an INSERT gets the id of the new row with a
+	// RETURNING clause instead of LastInsertId.
+	ReturnsInsertID() bool
+	// AbortsTransactionOnError tells whether a failed statement aborts its
+	// transaction, so the statements that may fail must run in savepoints for
+	// the transaction to go on.
+	AbortsTransactionOnError() bool
+
+	migrationsDir() string
+	// splitsStatements tells whether the driver runs one statement per call,
+	// so migration files must be split.
+	splitsStatements() bool
+	createSchemaMigrationsTable() string
+	// lock holds the migrations lock until the returned function is called.
+	lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error)
+}
+
+var (
+	MySQL    Dialect = mysqlDialect{}
+	Postgres Dialect = postgresDialect{}
+	SQLite   Dialect = sqliteDialect{}
+)
+
+// DialectFor returns the dialect of a DBConfig.DriverName.
+func DialectFor(driverName string) (Dialect, error) {
+	for _, dialect := range []Dialect{MySQL, Postgres, SQLite} {
+		if dialect.DriverName() == driverName {
+			return dialect, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown sql driver: %s", driverName)
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) DriverName() string {
+	return configs.MySQLDriverName
+}
+
+// DataSourceName enables clientFoundRows, so an UPDATE that leaves the row
+// unchanged still reports it as affected, like the other dialects do.
+func (mysqlDialect) DataSourceName(config *configs.DBConfig) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8&clientFoundRows=true&parseTime=true",
+		config.UserName,
+		config.Password,
+		config.Host,
+		config.DBName,
+	)
+}
+
+func (mysqlDialect) Rebind(query string) string {
+	return query
+}
+
+func (mysqlDialect) IsDuplicateKey(err error) bool {
+	driveErr, ok := err.(*mysql.MySQLError)
+	return ok && driveErr.Number == 1062
+}
+
+func (mysqlDialect) ReturnsInsertID() bool {
+	return false
+}
+
+func (mysqlDialect) AbortsTransactionOnError() bool {
+	return false
+}
+
+func (mysqlDialect) migrationsDir() string {
+	return "migrations/mysql"
+}
+
+func (mysqlDialect) splitsStatements() bool {
+	return true
+}
+
+func (mysqlDialect) createSchemaMigrationsTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint(20) NOT NULL,
+			name varchar(255) NOT NULL,
+			applied_at datetime NOT NULL,
+			PRIMARY KEY (version)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8;`
+}
+
+func (mysqlDialect) lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error) {
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?);", name, int(timeout.Seconds())).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("error trying to get the migrations lock: %w", err)
+	}
+	if !locked.Valid || locked.Int64 != 1 {
+		return nil, fmt.Errorf("timeout trying to get the migrations lock after %d seconds", int(timeout.Seconds()))
+	}
+
+	return func() {
+		conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?);", name)
+	}, nil
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) DriverName() string {
+	return configs.PostgresDriverName
+}
+
+func (postgresDialect) DataSourceName(config *configs.DBConfig) string {
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	dataSourceName := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.UserName, config.Password),
+		Host:     config.Host,
+		Path:     "/" + config.DBName,
+		RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+	}
+
+	return dataSourceName.String()
+}
+
+// Rebind numbers the placeholders, since Postgres uses $1, $2... The queries of
+// the repositories have no ? inside string literals.
+func (postgresDialect) Rebind(query string) string {
+	var rebound strings.Builder
+	position := 0
+
+	for _, char := range query {
+		if char == '?' {
+			position++
+			rebound.WriteString("$" + strconv.Itoa(position))
+			continue
+		}
+
+		rebound.WriteRune(char)
+	}
+
+	return rebound.String()
+}
+
+func (postgresDialect) IsDuplicateKey(err error) bool {
+	driveErr, ok := err.(*pq.Error)
+	return ok && driveErr.Code == "23505"
+}
+
+func (postgresDialect) ReturnsInsertID() bool {
+	return true
+}
+
+func (postgresDialect) AbortsTransactionOnError() bool {
+	return true
+}
+
+func (postgresDialect) migrationsDir() string {
+	return "migrations/postgres"
+}
+
+func (postgresDialect) splitsStatements() bool {
+	return false
+}
+
+func (postgresDialect) createSchemaMigrationsTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint NOT NULL,
+			name varchar(255) NOT NULL,
+			applied_at timestamp NOT NULL,
+			PRIMARY KEY (version)
+			);`
+}
+
+// postgresLockPollInterval is how often an advisory lock taken by another
+// instance is tried again.
+const postgresLockPollInterval = 500 * time.Millisecond
+
+func (postgresDialect) lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext($1));", name).Scan(&locked); err != nil {
+			return nil, fmt.Errorf("error trying to get the migrations lock: %w", err)
+		}
+
+		if locked {
+			return func() {
+				conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext($1));", name)
+			}, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timeout trying to get the migrations lock after %d seconds", int(timeout.Seconds()))
+		}
+
+		time.Sleep(postgresLockPollInterval)
+	}
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) DriverName() string {
+	return configs.SQLiteDriverName
+}
+
+// DataSourceName uses DBName as the path of the database file. Foreign keys
+// are enabled for the ON DELETE CASCADE of the tables, and transactions take
+// the write lock when they begin, so concurrent writers wait for each other.
+func (sqliteDialect) DataSourceName(config *configs.DBConfig) string {
+	return "file:" + config.DBName + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate"
+}
+
+func (sqliteDialect) Rebind(query string) string {
+	return query
+}
+
+func (sqliteDialect) IsDuplicateKey(err error) bool {
+	driveErr, ok := err.(*sqlite.Error)
+	return ok && (driveErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || driveErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE)
+}
+
+func (sqliteDialect) ReturnsInsertID() bool {
+	return false
+}
+
+func (sqliteDialect) AbortsTransactionOnError() bool {
+	return false
+}
+
+func (sqliteDialect) migrationsDir() string {
+	return "migrations/sqlite"
+}
+
+func (sqliteDialect) splitsStatements() bool {
+	return false
+}
+
+func (sqliteDialect) createSchemaMigrationsTable() string {
+	return `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER NOT NULL PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+			);`
+}
+
+// lock does nothing, since SQLite has no session locks. Every migration runs
+// in an immediate transaction that checks again whether it is applied.
+func (sqliteDialect) lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) (func(), error) {
+	return func() {}, nil
+}
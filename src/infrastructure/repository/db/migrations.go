@@ -0,0 +1,122 @@
+package db
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*/*.sql
+var embeddedMigrations embed.FS
+
+// migrationFileName matches the files of a migration, named
+// <version>_<name>.up.sql and <version>_<name>.down.sql.
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned change of the schema. Down reverts Up.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// EmbeddedMigrations returns the migrations of the dialect built into the
+// binary, sorted by version.
+func EmbeddedMigrations(dialect Dialect) ([]Migration, error) {
+	return LoadMigrations(embeddedMigrations, dialect.migrationsDir())
+}
+
+// LoadMigrations reads the migrations of dir, sorted by version. Every version
+// must have both an up and a down file.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to read migrations: %w", err)
+	}
+
+	migrationsByVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error trying to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := migrationsByVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationsByVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(migrationsByVersion))
+	for _, migration := range migrationsByVersion {
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s must have an up and a down file", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// splitStatements splits the content of a migration file in the statements
+// ended by a semicolon at the end of a line, since the driver runs one
+// statement per call. Comment lines are left out.
+func splitStatements(content string) []string {
+	var statements []string
+	var statement strings.Builder
+
+	for _, line := range strings.Split(content, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+
+		statement.WriteString(line)
+		statement.WriteString("\n")
+
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			statements = append(statements, strings.TrimSpace(statement.String()))
+			statement.Reset()
+		}
+	}
+
+	if rest := strings.TrimSpace(statement.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+
+	return statements
+}
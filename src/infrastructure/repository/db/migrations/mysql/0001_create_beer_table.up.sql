@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS beer (
+	id bigint(20) NOT NULL AUTO_INCREMENT,
+	name varchar(45) COLLATE utf8_spanish2_ci DEFAULT NULL,
+	brewery varchar(45) COLLATE utf8_spanish2_ci DEFAULT NULL,
+	country varchar(45) COLLATE utf8_spanish2_ci NOT NULL,
+	price decimal(10,2) DEFAULT NULL,
+	currency varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;
+
+-- Upgrades tables created before ids were generated by the database.
+ALTER TABLE beer MODIFY id bigint(20) NOT NULL AUTO_INCREMENT;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pricing_rule (
+	id bigint(20) NOT NULL AUTO_INCREMENT,
+	beer_id bigint(20) DEFAULT NULL,
+	name varchar(45) COLLATE utf8_spanish2_ci NOT NULL,
+	type varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	min_quantity bigint(20) unsigned NOT NULL DEFAULT 0,
+	percentage decimal(5,2) NOT NULL DEFAULT 0,
+	buy_quantity bigint(20) unsigned NOT NULL DEFAULT 0,
+	PRIMARY KEY (id),
+	KEY pricing_rule_beer_id (beer_id),
+	CONSTRAINT pricing_rule_beer_fk FOREIGN KEY (beer_id) REFERENCES beer (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS coupon (
+	id bigint(20) NOT NULL AUTO_INCREMENT,
+	code varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	type varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	percentage decimal(5,2) NOT NULL DEFAULT 0,
+	amount decimal(10,2) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE utf8_spanish2_ci NOT NULL DEFAULT '',
+	valid_from datetime DEFAULT NULL,
+	valid_until datetime DEFAULT NULL,
+	max_uses bigint(20) unsigned NOT NULL DEFAULT 0,
+	uses bigint(20) unsigned NOT NULL DEFAULT 0,
+	beer_id bigint(20) DEFAULT NULL,
+	brewery varchar(45) COLLATE utf8_spanish2_ci NOT NULL DEFAULT '',
+	country varchar(45) COLLATE utf8_spanish2_ci NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	UNIQUE KEY coupon_code (code),
+	CONSTRAINT coupon_beer_fk FOREIGN KEY (beer_id) REFERENCES beer (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;
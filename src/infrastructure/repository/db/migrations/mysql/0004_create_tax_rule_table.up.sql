@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tax_rule (
+	id bigint(20) NOT NULL AUTO_INCREMENT,
+	country char(2) COLLATE utf8_spanish2_ci NOT NULL,
+	category varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	name varchar(45) COLLATE utf8_spanish2_ci NOT NULL,
+	type varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	percentage decimal(5,2) NOT NULL DEFAULT 0,
+	amount decimal(10,4) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE utf8_spanish2_ci NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	KEY tax_rule_country (country)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;
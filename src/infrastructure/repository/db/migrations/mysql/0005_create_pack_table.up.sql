@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pack (
+	id bigint(20) NOT NULL AUTO_INCREMENT,
+	beer_id bigint(20) NOT NULL,
+	name varchar(45) COLLATE utf8_spanish2_ci NOT NULL,
+	size bigint(20) unsigned NOT NULL,
+	surcharge decimal(10,2) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE utf8_spanish2_ci NOT NULL,
+	PRIMARY KEY (id),
+	UNIQUE KEY pack_beer_size (beer_id, size),
+	CONSTRAINT pack_beer_fk FOREIGN KEY (beer_id) REFERENCES beer (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8 COLLATE=utf8_spanish2_ci;
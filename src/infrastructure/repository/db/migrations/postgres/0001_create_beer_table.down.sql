@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS beer;
+DROP FUNCTION IF EXISTS beer_assign_id();
+DROP SEQUENCE IF EXISTS beer_id_seq;
+DROP COLLATION IF EXISTS case_insensitive;
@@ -0,0 +1,30 @@
+-- Text columns compare ignoring case, like the utf8_spanish2_ci collation of
+-- the MySQL tables.
+CREATE COLLATION IF NOT EXISTS case_insensitive (provider = icu, locale = 'und-u-ks-level2', deterministic = false);
+
+CREATE SEQUENCE beer_id_seq;
+
+CREATE TABLE beer (
+	id bigint NOT NULL,
+	name varchar(45) COLLATE case_insensitive DEFAULT NULL,
+	brewery varchar(45) COLLATE case_insensitive DEFAULT NULL,
+	country varchar(45) COLLATE case_insensitive NOT NULL,
+	price numeric(10,2) DEFAULT NULL,
+	currency varchar(32) COLLATE case_insensitive NOT NULL,
+	PRIMARY KEY (id)
+);
+
+-- Beers are saved with a NULL id when the database must assign one. Like
+-- AUTO_INCREMENT, the sequence also moves past the ids given explicitly.
+CREATE FUNCTION beer_assign_id() RETURNS trigger AS $$
+BEGIN
+	IF NEW.id IS NULL THEN
+		NEW.id := nextval('beer_id_seq');
+	ELSE
+		PERFORM setval('beer_id_seq', GREATEST(NEW.id, (SELECT last_value FROM beer_id_seq)));
+	END IF;
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER beer_assign_id BEFORE INSERT ON beer FOR EACH ROW EXECUTE FUNCTION beer_assign_id();
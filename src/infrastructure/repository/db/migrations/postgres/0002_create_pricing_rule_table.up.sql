@@ -0,0 +1,12 @@
+CREATE TABLE pricing_rule (
+	id bigserial NOT NULL,
+	beer_id bigint DEFAULT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	name varchar(45) COLLATE case_insensitive NOT NULL,
+	type varchar(32) COLLATE case_insensitive NOT NULL,
+	min_quantity bigint NOT NULL DEFAULT 0,
+	percentage numeric(5,2) NOT NULL DEFAULT 0,
+	buy_quantity bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (id)
+);
+
+CREATE INDEX pricing_rule_beer_id ON pricing_rule (beer_id);
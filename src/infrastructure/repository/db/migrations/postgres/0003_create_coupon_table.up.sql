@@ -0,0 +1,17 @@
+CREATE TABLE coupon (
+	id bigserial NOT NULL,
+	code varchar(32) COLLATE case_insensitive NOT NULL,
+	type varchar(32) COLLATE case_insensitive NOT NULL,
+	percentage numeric(5,2) NOT NULL DEFAULT 0,
+	amount numeric(10,2) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE case_insensitive NOT NULL DEFAULT '',
+	valid_from timestamp DEFAULT NULL,
+	valid_until timestamp DEFAULT NULL,
+	max_uses bigint NOT NULL DEFAULT 0,
+	uses bigint NOT NULL DEFAULT 0,
+	beer_id bigint DEFAULT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	brewery varchar(45) COLLATE case_insensitive NOT NULL DEFAULT '',
+	country varchar(45) COLLATE case_insensitive NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	CONSTRAINT coupon_code UNIQUE (code)
+);
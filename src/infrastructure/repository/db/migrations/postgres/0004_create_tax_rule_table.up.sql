@@ -0,0 +1,13 @@
+CREATE TABLE tax_rule (
+	id bigserial NOT NULL,
+	country char(2) COLLATE case_insensitive NOT NULL,
+	category varchar(32) COLLATE case_insensitive NOT NULL,
+	name varchar(45) COLLATE case_insensitive NOT NULL,
+	type varchar(32) COLLATE case_insensitive NOT NULL,
+	percentage numeric(5,2) NOT NULL DEFAULT 0,
+	amount numeric(10,4) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE case_insensitive NOT NULL DEFAULT '',
+	PRIMARY KEY (id)
+);
+
+CREATE INDEX tax_rule_country ON tax_rule (country);
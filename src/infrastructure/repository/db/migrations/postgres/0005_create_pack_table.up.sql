@@ -0,0 +1,10 @@
+CREATE TABLE pack (
+	id bigserial NOT NULL,
+	beer_id bigint NOT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	name varchar(45) COLLATE case_insensitive NOT NULL,
+	size bigint NOT NULL,
+	surcharge numeric(10,2) NOT NULL DEFAULT 0,
+	currency varchar(32) COLLATE case_insensitive NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT pack_beer_size UNIQUE (beer_id, size)
+);
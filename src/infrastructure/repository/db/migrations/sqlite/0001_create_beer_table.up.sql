@@ -0,0 +1,11 @@
+-- AUTOINCREMENT never reuses ids and moves past the ids given explicitly, like
+-- AUTO_INCREMENT. NOCASE compares text ignoring case, like the
+-- utf8_spanish2_ci collation of the MySQL tables.
+CREATE TABLE beer (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT COLLATE NOCASE DEFAULT NULL,
+	brewery TEXT COLLATE NOCASE DEFAULT NULL,
+	country TEXT COLLATE NOCASE NOT NULL,
+	price NUMERIC DEFAULT NULL,
+	currency TEXT COLLATE NOCASE NOT NULL
+);
@@ -0,0 +1,11 @@
+CREATE TABLE pricing_rule (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	beer_id INTEGER DEFAULT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	name TEXT COLLATE NOCASE NOT NULL,
+	type TEXT COLLATE NOCASE NOT NULL,
+	min_quantity INTEGER NOT NULL DEFAULT 0,
+	percentage NUMERIC NOT NULL DEFAULT 0,
+	buy_quantity INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX pricing_rule_beer_id ON pricing_rule (beer_id);
@@ -0,0 +1,16 @@
+CREATE TABLE coupon (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	code TEXT COLLATE NOCASE NOT NULL,
+	type TEXT COLLATE NOCASE NOT NULL,
+	percentage NUMERIC NOT NULL DEFAULT 0,
+	amount NUMERIC NOT NULL DEFAULT 0,
+	currency TEXT COLLATE NOCASE NOT NULL DEFAULT '',
+	valid_from DATETIME DEFAULT NULL,
+	valid_until DATETIME DEFAULT NULL,
+	max_uses INTEGER NOT NULL DEFAULT 0,
+	uses INTEGER NOT NULL DEFAULT 0,
+	beer_id INTEGER DEFAULT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	brewery TEXT COLLATE NOCASE NOT NULL DEFAULT '',
+	country TEXT COLLATE NOCASE NOT NULL DEFAULT '',
+	CONSTRAINT coupon_code UNIQUE (code)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE tax_rule (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	country TEXT COLLATE NOCASE NOT NULL,
+	category TEXT COLLATE NOCASE NOT NULL,
+	name TEXT COLLATE NOCASE NOT NULL,
+	type TEXT COLLATE NOCASE NOT NULL,
+	percentage NUMERIC NOT NULL DEFAULT 0,
+	amount NUMERIC NOT NULL DEFAULT 0,
+	currency TEXT COLLATE NOCASE NOT NULL DEFAULT ''
+);
+
+CREATE INDEX tax_rule_country ON tax_rule (country);
@@ -0,0 +1,9 @@
+CREATE TABLE pack (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	beer_id INTEGER NOT NULL REFERENCES beer (id) ON DELETE CASCADE,
+	name TEXT COLLATE NOCASE NOT NULL,
+	size INTEGER NOT NULL,
+	surcharge NUMERIC NOT NULL DEFAULT 0,
+	currency TEXT COLLATE NOCASE NOT NULL,
+	CONSTRAINT pack_beer_size UNIQUE (beer_id, size)
+);
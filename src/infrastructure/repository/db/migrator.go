@@ -0,0 +1,254 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	queryListAppliedMigrations  = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version ASC;"
+	queryCountAppliedMigration  = "SELECT COUNT(*) FROM schema_migrations WHERE version=?;"
+	queryInsertAppliedMigration = "INSERT INTO schema_migrations(version, name, applied_at) VALUES(?, ?, ?);"
+	queryDeleteAppliedMigration = "DELETE FROM schema_migrations WHERE version=?;"
+
+	migrationsLockName = "beers_api_schema_migrations"
+	// migrationsLockTimeout is how long an instance waits for another one to
+	// finish its migrations.
+	migrationsLockTimeout = 60 * time.Second
+)
+
+// MigrationStatus tells whether a migration is applied. AppliedAt is nil for a
+// pending migration.
+type MigrationStatus struct {
+	Migration Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies and reverts migrations, tracking the applied ones in the
+// schema_migrations table. Up and Down hold a database lock, and every
+// migration checks again in its transaction whether it is applied, so
+// instances started at the same time do not apply the same migration twice.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, dialect Dialect, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}
+}
+
+// Up applies the pending migrations in version order and returns them. It
+// stops at the first failing migration.
+func (m *Migrator) Up() ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(func(conn *sql.Conn) error {
+		appliedAt, err := m.listApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedAt[migration.Version]; ok {
+				continue
+			}
+
+			done, err := m.apply(conn, migration, true)
+			if err != nil {
+				return fmt.Errorf("error trying to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			if done {
+				applied = append(applied, migration)
+			}
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// them.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(func(conn *sql.Conn) error {
+		appliedAt, err := m.listApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		migrationsByVersion := make(map[int64]Migration, len(m.migrations))
+		for _, migration := range m.migrations {
+			migrationsByVersion[migration.Version] = migration
+		}
+
+		for _, version := range latestVersions(appliedAt, steps) {
+			migration, ok := migrationsByVersion[version]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but unknown to this binary", version)
+			}
+
+			done, err := m.apply(conn, migration, false)
+			if err != nil {
+				return fmt.Errorf("error trying to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			if done {
+				reverted = append(reverted, migration)
+			}
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status returns every known migration with the time it was applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to get a database connection: %w", err)
+	}
+	defer conn.Close()
+
+	appliedAt, err := m.listApplied(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Migration: migration}
+		if timestamp, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &timestamp
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// withLock runs fn holding the migrations lock. The lock belongs to a database
+// session, so fn gets the connection holding it.
+func (m *Migrator) withLock(fn func(conn *sql.Conn) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("error trying to get a database connection: %w", err)
+	}
+	defer conn.Close()
+
+	unlock, err := m.dialect.lock(ctx, conn, migrationsLockName, migrationsLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return fn(conn)
+}
+
+func (m *Migrator) listApplied(conn *sql.Conn) (map[int64]time.Time, error) {
+	ctx := context.Background()
+	if _, err := conn.ExecContext(ctx, m.dialect.createSchemaMigrationsTable()); err != nil {
+		return nil, fmt.Errorf("error trying to create the schema_migrations table: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, queryListAppliedMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("error trying to list applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var name string
+		var timestamp time.Time
+
+		if err := rows.Scan(&version, &name, &timestamp); err != nil {
+			return nil, fmt.Errorf("error trying to scan applied migrations: %w", err)
+		}
+
+		appliedAt[version] = timestamp
+	}
+
+	return appliedAt, rows.Err()
+}
+
+// apply runs the up or down file of the migration and records it in one
+// transaction, and returns false when another instance already did it.
+// Databases that commit DDL implicitly, like MySQL, only keep the tracking row
+// atomic.
+func (m *Migrator) apply(conn *sql.Conn, migration Migration, up bool) (bool, error) {
+	ctx := context.Background()
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := tx.QueryRowContext(ctx, m.dialect.Rebind(queryCountAppliedMigration), migration.Version).Scan(&count); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if (count > 0) == up {
+		return false, tx.Rollback()
+	}
+
+	content, trackingQuery, trackingArgs := migration.Down, queryDeleteAppliedMigration, []interface{}{migration.Version}
+	if up {
+		content, trackingQuery = migration.Up, queryInsertAppliedMigration
+		trackingArgs = append(trackingArgs, migration.Name, time.Now().UTC())
+	}
+
+	statements := []string{content}
+	if m.dialect.splitsStatements() {
+		statements = splitStatements(content)
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, m.dialect.Rebind(trackingQuery), trackingArgs...); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func latestVersions(appliedAt map[int64]time.Time, count int) []int64 {
+	versions := make([]int64, 0, len(appliedAt))
+	for version := range appliedAt {
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] > versions[j]
+	})
+
+	if count < len(versions) {
+		versions = versions[:count]
+	}
+
+	return versions
+}
@@ -0,0 +1,209 @@
+package db_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	queryCreateSchemaMigrationsTableTest = "CREATE TABLE IF NOT EXISTS schema_migrations ( version bigint(20) NOT NULL, " +
+		"name varchar(255) NOT NULL, applied_at datetime NOT NULL, PRIMARY KEY (version) ) ENGINE=InnoDB DEFAULT CHARSET=utf8;"
+	queryListAppliedMigrationsTest  = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version ASC;"
+	queryCountAppliedMigrationTest  = "SELECT COUNT(*) FROM schema_migrations WHERE version=?;"
+	queryInsertAppliedMigrationTest = "INSERT INTO schema_migrations(version, name, applied_at) VALUES(?, ?, ?);"
+	queryDeleteAppliedMigrationTest = "DELETE FROM schema_migrations WHERE version=?;"
+	queryGetMigrationsLockTest      = "SELECT GET_LOCK(?, ?);"
+	queryReleaseMigrationsLockTest  = "SELECT RELEASE_LOCK(?);"
+)
+
+func Test_LoadMigrations_WhenFilesAreValid_ThenReturnMigrationsSortedByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_create_pack_table.up.sql":   {Data: []byte("CREATE TABLE pack (id bigint);")},
+		"migrations/0002_create_pack_table.down.sql": {Data: []byte("DROP TABLE pack;")},
+		"migrations/0001_create_beer_table.up.sql":   {Data: []byte("CREATE TABLE beer (id bigint);")},
+		"migrations/0001_create_beer_table.down.sql": {Data: []byte("DROP TABLE beer;")},
+	}
+
+	migrations, err := db.LoadMigrations(fsys, "migrations")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []db.Migration{
+		{Version: 1, Name: "create_beer_table", Up: "CREATE TABLE beer (id bigint);", Down: "DROP TABLE beer;"},
+		{Version: 2, Name: "create_pack_table", Up: "CREATE TABLE pack (id bigint);", Down: "DROP TABLE pack;"},
+	}, migrations)
+}
+
+func Test_LoadMigrations_WhenDownFileIsMissing_ThenReturnError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0001_create_beer_table.up.sql": {Data: []byte("CREATE TABLE beer (id bigint);")},
+	}
+
+	migrations, err := db.LoadMigrations(fsys, "migrations")
+
+	assert.Nil(t, migrations)
+	assert.EqualError(t, err, "migration 1_create_beer_table must have an up and a down file")
+}
+
+func Test_LoadMigrations_WhenFileNameIsInvalid_ThenReturnError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/create_beer_table.sql": {Data: []byte("CREATE TABLE beer (id bigint);")},
+	}
+
+	migrations, err := db.LoadMigrations(fsys, "migrations")
+
+	assert.Nil(t, migrations)
+	assert.EqualError(t, err, "invalid migration file name: create_beer_table.sql")
+}
+
+func Test_EmbeddedMigrations_WhenLoaded_ThenReturnTheMigrationsOfEveryTable(t *testing.T) {
+	migrations, err := db.EmbeddedMigrations(db.MySQL)
+
+	assert.Nil(t, err)
+	names := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		names = append(names, migration.Name)
+	}
+	assert.Equal(t, []string{
+		"create_beer_table", "create_pricing_rule_table", "create_coupon_table", "create_tax_rule_table", "create_pack_table",
+	}, names)
+}
+
+func Test_Up_WhenLockIsNotAcquired_ThenReturnErrorAndApplyNothing(t *testing.T) {
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(queryGetMigrationsLockTest).WillReturnRows(mock.NewRows([]string{"lock"}).AddRow(0))
+	migrator := db.NewMigrator(client, db.MySQL, givenMigrations())
+
+	applied, err := migrator.Up()
+
+	assert.Nil(t, applied)
+	assert.EqualError(t, err, "timeout trying to get the migrations lock after 60 seconds")
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Up_WhenSomeMigrationsAreApplied_ThenApplyOnlyThePendingOnes(t *testing.T) {
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(queryGetMigrationsLockTest).WillReturnRows(mock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectExec(queryCreateSchemaMigrationsTableTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryListAppliedMigrationsTest).WillReturnRows(
+		mock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "create_beer_table", time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryCountAppliedMigrationTest).WithArgs(int64(2)).
+		WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("CREATE TABLE pack (id bigint);").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE INDEX pack_id ON pack (id);").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(queryInsertAppliedMigrationTest).WithArgs(int64(2), "create_pack_table", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(queryReleaseMigrationsLockTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	migrations := givenMigrations()
+	migrator := db.NewMigrator(client, db.MySQL, migrations)
+
+	applied, err := migrator.Up()
+
+	assert.Nil(t, err)
+	assert.Equal(t, migrations[1:], applied)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Up_WhenAMigrationFail_ThenRollbackAndStop(t *testing.T) {
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(queryGetMigrationsLockTest).WillReturnRows(mock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectExec(queryCreateSchemaMigrationsTableTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryListAppliedMigrationsTest).WillReturnRows(mock.NewRows([]string{"version", "name", "applied_at"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryCountAppliedMigrationTest).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("CREATE TABLE beer (id bigint);").WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+	mock.ExpectExec(queryReleaseMigrationsLockTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	migrator := db.NewMigrator(client, db.MySQL, givenMigrations())
+
+	applied, err := migrator.Up()
+
+	assert.Nil(t, applied)
+	assert.EqualError(t, err, "error trying to apply migration 1_create_beer_table: "+assert.AnError.Error())
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Down_WhenMigrationsAreApplied_ThenRevertTheLatestOne(t *testing.T) {
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectQuery(queryGetMigrationsLockTest).WillReturnRows(mock.NewRows([]string{"lock"}).AddRow(1))
+	mock.ExpectExec(queryCreateSchemaMigrationsTableTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryListAppliedMigrationsTest).WillReturnRows(
+		mock.NewRows([]string{"version", "name", "applied_at"}).
+			AddRow(1, "create_beer_table", time.Now()).
+			AddRow(2, "create_pack_table", time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectQuery(queryCountAppliedMigrationTest).WillReturnRows(mock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("DROP TABLE pack;").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(queryDeleteAppliedMigrationTest).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(queryReleaseMigrationsLockTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	migrations := givenMigrations()
+	migrator := db.NewMigrator(client, db.MySQL, migrations)
+
+	reverted, err := migrator.Down(1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, migrations[1:], reverted)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Status_WhenOneMigrationIsPending_ThenReturnItWithoutAppliedAt(t *testing.T) {
+	appliedAt := time.Date(2021, 11, 19, 10, 30, 0, 0, time.UTC)
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectExec(queryCreateSchemaMigrationsTableTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryListAppliedMigrationsTest).WillReturnRows(
+		mock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "create_beer_table", appliedAt))
+	migrations := givenMigrations()
+	migrator := db.NewMigrator(client, db.MySQL, migrations)
+
+	statuses, err := migrator.Status()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []db.MigrationStatus{
+		{Migration: migrations[0], AppliedAt: &appliedAt},
+		{Migration: migrations[1]},
+	}, statuses)
+}
+
+func givenMigrations() []db.Migration {
+	return []db.Migration{
+		{Version: 1, Name: "create_beer_table", Up: "CREATE TABLE beer (id bigint);", Down: "DROP TABLE beer;"},
+		{
+			Version: 2,
+			Name:    "create_pack_table",
+			Up:      "CREATE TABLE pack (id bigint);\n\n-- Speeds up the lookups by id.\nCREATE INDEX pack_id ON pack (id);\n",
+			Down:    "DROP TABLE pack;",
+		},
+	}
+}
+
+func Test_Up_WhenDialectIsSQLite_ThenApplyEmbeddedMigrationsOnceAndDownRevertsThem(t *testing.T) {
+	client, openErr := db.NewSqlDB(&configs.DBConfig{DriverName: configs.SQLiteDriverName, DBName: t.TempDir() + "/beers.db"}, db.SQLite)
+	if openErr != nil {
+		t.Fatal(openErr)
+	}
+	defer client.Close()
+	migrations, _ := db.EmbeddedMigrations(db.SQLite)
+	migrator := db.NewMigrator(client, db.SQLite, migrations)
+
+	applied, err := migrator.Up()
+	appliedAgain, errAgain := migrator.Up()
+	reverted, downErr := migrator.Down(len(migrations))
+
+	assert.Nil(t, err)
+	assert.Equal(t, migrations, applied)
+	assert.Nil(t, errAgain)
+	assert.Empty(t, appliedAgain)
+	assert.Nil(t, downErr)
+	assert.Len(t, reverted, len(migrations))
+	var tables int
+	client.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('beer', 'pack');").Scan(&tables)
+	assert.Equal(t, 0, tables)
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type memoryBeerRepository struct {
+	store *memoryStore
+}
+
+// NewMemoryBeerRepository returns a BeerRepository keeping the beers in store,
+// with the same filters, sort and errors as the MySQL one.
+func NewMemoryBeerRepository(store *memoryStore) *memoryBeerRepository {
+	return &memoryBeerRepository{
+		store: store,
+	}
+}
+
+func (r *memoryBeerRepository) List(query entities.BeerQuery) ([]entities.Beer, *errors.RestError) {
+	beers := r.filter(query)
+
+	if query.Offset >= len(beers) {
+		return make([]entities.Beer, 0), nil
+	}
+
+	end := query.Offset + query.Limit
+	if end > len(beers) {
+		end = len(beers)
+	}
+
+	return beers[query.Offset:end], nil
+}
+
+func (r *memoryBeerRepository) Count(query entities.BeerQuery) (int64, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var total int64
+	for _, beer := range r.store.beers {
+		if matchesBeerQuery(beer, query) {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// Stream calls fn with a snapshot of the matching beers, taken before the first
+// call, so fn can use the repository without a deadlock.
+func (r *memoryBeerRepository) Stream(query entities.BeerQuery, fn func(beer entities.Beer) *errors.RestError) *errors.RestError {
+	for _, beer := range r.filter(query) {
+		if restErr := fn(beer); restErr != nil {
+			return restErr
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryBeerRepository) ListCurrencies(query entities.BeerQuery) ([]string, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	currencies := make([]string, 0)
+	for _, beer := range r.store.beers {
+		if matchesBeerQuery(beer, query) && !seen[beer.Currency] {
+			seen[beer.Currency] = true
+			currencies = append(currencies, beer.Currency)
+		}
+	}
+
+	return currencies, nil
+}
+
+func (r *memoryBeerRepository) GetByID(beerID int64) (*entities.Beer, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	beer, ok := r.store.beers[beerID]
+	if !ok {
+		return nil, errors.NewNotFoundError("beer not found")
+	}
+
+	return &beer, nil
+}
+
+func (r *memoryBeerRepository) Save(beer entities.Beer) (int64, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.save(beer)
+}
+
+// SaveBatch saves the beers holding the lock for the whole batch. When atomic
+// and any beer fails, the saved ones are removed again and no result has an id.
+func (r *memoryBeerRepository) SaveBatch(beers []entities.Beer, atomic bool) ([]entities.BeerSaveResult, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	nextBeerID := r.store.nextBeerID
+	results := make([]entities.BeerSaveResult, len(beers))
+	failed := false
+	for i, beer := range beers {
+		results[i].Id, results[i].Err = r.save(beer)
+		failed = failed || results[i].Err != nil
+	}
+
+	if atomic && failed {
+		for i := range results {
+			if results[i].Err == nil {
+				delete(r.store.beers, results[i].Id)
+			}
+			results[i].Id = 0
+		}
+		r.store.nextBeerID = nextBeerID
+	}
+
+	return results, nil
+}
+
+func (r *memoryBeerRepository) Update(beer entities.Beer) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.beers[beer.Id]; !ok {
+		return errors.NewNotFoundError("beer not found")
+	}
+
+	r.store.beers[beer.Id] = beer
+	return nil
+}
+
+func (r *memoryBeerRepository) Delete(beerID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.beers[beerID]; !ok {
+		return errors.NewNotFoundError("beer not found")
+	}
+
+	delete(r.store.beers, beerID)
+	r.store.deleteBeerCascade(beerID)
+	return nil
+}
+
+// save inserts the beer, assigning the next id when it has none. The caller
+// holds the lock.
+func (r *memoryBeerRepository) save(beer entities.Beer) (int64, *errors.RestError) {
+	if beer.Id == 0 {
+		beer.Id = r.store.nextBeerID
+	}
+
+	if _, ok := r.store.beers[beer.Id]; ok {
+		return 0, errors.NewConflictError(
+			fmt.Sprintf("beer id %d already exists", beer.Id))
+	}
+
+	r.store.beers[beer.Id] = beer
+	if beer.Id >= r.store.nextBeerID {
+		r.store.nextBeerID = beer.Id + 1
+	}
+
+	return beer.Id, nil
+}
+
+func (r *memoryBeerRepository) filter(query entities.BeerQuery) []entities.Beer {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	beers := make([]entities.Beer, 0)
+	for _, beer := range r.store.beers {
+		if matchesBeerQuery(beer, query) {
+			beers = append(beers, beer)
+		}
+	}
+
+	sort.Slice(beers, func(i, j int) bool {
+		return lessBeer(beers[i], beers[j], query.Sort)
+	})
+
+	return beers
+}
+
+// matchesBeerQuery compares the text filters ignoring case, like the case
+// insensitive collation of the MySQL table.
+func matchesBeerQuery(beer entities.Beer, query entities.BeerQuery) bool {
+	if query.Country != "" && !strings.EqualFold(beer.Country, query.Country) {
+		return false
+	}
+
+	if query.Brewery != "" && !strings.EqualFold(beer.Brewery, query.Brewery) {
+		return false
+	}
+
+	if query.Currency != "" && !strings.EqualFold(beer.Currency, query.Currency) {
+		return false
+	}
+
+	if query.MinPrice != nil && beer.Price.LessThan(*query.MinPrice) {
+		return false
+	}
+
+	if query.MaxPrice != nil && beer.Price.GreaterThan(*query.MaxPrice) {
+		return false
+	}
+
+	return true
+}
+
+// lessBeer orders by the sort fields and then by id, like buildBeerOrderBy.
+func lessBeer(a, b entities.Beer, sortFields []entities.SortField) bool {
+	for _, sortField := range sortFields {
+		var compare int
+		switch sortField.Field {
+		case "id":
+			compare = compareInt64(a.Id, b.Id)
+		case "name":
+			compare = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case "brewery":
+			compare = strings.Compare(strings.ToLower(a.Brewery), strings.ToLower(b.Brewery))
+		case "country":
+			compare = strings.Compare(strings.ToLower(a.Country), strings.ToLower(b.Country))
+		case "price":
+			compare = a.Price.Cmp(b.Price)
+		case "currency":
+			compare = strings.Compare(strings.ToLower(a.Currency), strings.ToLower(b.Currency))
+		}
+
+		if sortField.Descending {
+			compare = -compare
+		}
+
+		if compare != 0 {
+			return compare < 0
+		}
+	}
+
+	return a.Id < b.Id
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,165 @@
+package repository_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryGetByID_WhenBeerDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+
+	beer, err := repo.GetByID(1)
+
+	assert.Nil(t, beer)
+	assert.Equal(t, errors.NewNotFoundError("beer not found"), err)
+}
+
+func Test_MemorySave_WhenBeerIdExists_ThenReturnConflictError(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	repo.Save(*givenBeer())
+
+	id, err := repo.Save(*givenBeer())
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, errors.NewConflictError("beer id 1 already exists"), err)
+}
+
+func Test_MemorySave_WhenBeerHasNoId_ThenReturnIdAfterTheHighestOne(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	beer := givenBeer()
+	beer.Id = 7
+	repo.Save(*beer)
+	beer.Id = 0
+
+	id, err := repo.Save(*beer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), id)
+	saved, _ := repo.GetByID(8)
+	assert.Equal(t, int64(8), saved.Id)
+}
+
+func Test_MemoryList_WhenQueryHasFiltersSortAndPagination_ThenReturnMatchingPage(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	givenMemoryBeers(repo,
+		entities.Beer{Id: 1, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(2500), Currency: "COP"},
+		entities.Beer{Id: 2, Name: "Club", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(3000), Currency: "COP"},
+		entities.Beer{Id: 3, Name: "Aguila", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(2500), Currency: "COP"},
+		entities.Beer{Id: 4, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: decimal.NewFromInt(2), Currency: "USD"})
+	minPrice := decimal.NewFromInt(2000)
+	query := entities.NewBeerQuery()
+	query.Country = "colombia"
+	query.MinPrice = &minPrice
+	query.Sort = []entities.SortField{{Field: "price", Descending: true}, {Field: "name"}}
+	query.Limit = 2
+	query.Offset = 1
+
+	beers, err := repo.List(query)
+	total, countErr := repo.Count(query)
+
+	assert.Nil(t, err)
+	assert.Nil(t, countErr)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, []int64{3, 1}, beerIDs(beers))
+}
+
+func Test_MemorySaveBatch_WhenAtomicAndOneBeerFail_ThenSaveNothing(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	givenMemoryBeers(repo, *givenBeer())
+	beer := givenBeer()
+	beer.Id = 0
+
+	results, err := repo.SaveBatch([]entities.Beer{*beer, *givenBeer()}, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{},
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+	}, results)
+	total, _ := repo.Count(entities.NewBeerQuery())
+	assert.Equal(t, int64(1), total)
+}
+
+func Test_MemorySaveBatch_WhenNotAtomicAndOneBeerFail_ThenSaveTheOthers(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	givenMemoryBeers(repo, *givenBeer())
+	beer := givenBeer()
+	beer.Id = 0
+
+	results, err := repo.SaveBatch([]entities.Beer{*givenBeer(), *beer}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+		{Id: 2},
+	}, results)
+}
+
+func Test_MemoryUpdate_WhenBeerDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+
+	err := repo.Update(*givenBeer())
+
+	assert.Equal(t, errors.NewNotFoundError("beer not found"), err)
+}
+
+func Test_MemoryDelete_WhenBeerHasPacks_ThenDeleteThemToo(t *testing.T) {
+	store := repository.NewMemoryStore()
+	repo := repository.NewMemoryBeerRepository(store)
+	packRepo := repository.NewMemoryPackRepository(store)
+	givenMemoryBeers(repo, *givenBeer())
+	packID, _ := packRepo.Save(entities.Pack{BeerId: 1, Name: "Six pack", Size: 6, Currency: "COP"})
+
+	err := repo.Delete(1)
+
+	assert.Nil(t, err)
+	_, packErr := packRepo.GetByID(packID)
+	assert.Equal(t, errors.NewNotFoundError("pack not found"), packErr)
+	assert.Equal(t, errors.NewNotFoundError("beer not found"), repo.Delete(1))
+}
+
+func Test_MemorySave_WhenCalledConcurrently_ThenGenerateUniqueIds(t *testing.T) {
+	repo := repository.NewMemoryBeerRepository(repository.NewMemoryStore())
+	beer := givenBeer()
+	beer.Id = 0
+	ids := make([]int64, 50)
+
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i], _ = repo.Save(*beer)
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool)
+	for _, id := range ids {
+		seen[id] = true
+	}
+	assert.Len(t, seen, len(ids))
+	assert.False(t, seen[0])
+}
+
+func givenMemoryBeers(repo services.BeerRepository, beers ...entities.Beer) {
+	for _, beer := range beers {
+		repo.Save(beer)
+	}
+}
+
+func beerIDs(beers []entities.Beer) []int64 {
+	ids := make([]int64, 0, len(beers))
+	for _, beer := range beers {
+		ids = append(ids, beer.Id)
+	}
+
+	return ids
+}
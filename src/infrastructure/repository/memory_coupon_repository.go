@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type memoryCouponRepository struct {
+	store *memoryStore
+}
+
+func NewMemoryCouponRepository(store *memoryStore) *memoryCouponRepository {
+	return &memoryCouponRepository{
+		store: store,
+	}
+}
+
+func (r *memoryCouponRepository) List() ([]entities.Coupon, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	coupons := make([]entities.Coupon, 0, len(r.store.coupons))
+	for _, coupon := range r.store.coupons {
+		coupons = append(coupons, copyCoupon(coupon))
+	}
+
+	sort.Slice(coupons, func(i, j int) bool {
+		return coupons[i].Id < coupons[j].Id
+	})
+
+	return coupons, nil
+}
+
+func (r *memoryCouponRepository) GetByID(couponID int64) (*entities.Coupon, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	coupon, ok := r.store.coupons[couponID]
+	if !ok {
+		return nil, errors.NewNotFoundError("coupon not found")
+	}
+
+	coupon = copyCoupon(coupon)
+	return &coupon, nil
+}
+
+// GetByCode ignores case, like the case insensitive collation of the MySQL
+// table.
+func (r *memoryCouponRepository) GetByCode(code string) (*entities.Coupon, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, coupon := range r.store.coupons {
+		if strings.EqualFold(coupon.Code, code) {
+			coupon = copyCoupon(coupon)
+			return &coupon, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError("coupon not found")
+}
+
+func (r *memoryCouponRepository) Save(coupon entities.Coupon) (int64, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	coupon = copyCoupon(coupon)
+	coupon.Id = r.store.nextCouponID
+	if restErr := r.checkUniqueCode(coupon); restErr != nil {
+		return 0, restErr
+	}
+
+	r.store.coupons[coupon.Id] = coupon
+	r.store.nextCouponID++
+
+	return coupon.Id, nil
+}
+
+func (r *memoryCouponRepository) Update(coupon entities.Coupon) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.coupons[coupon.Id]; !ok {
+		return errors.NewNotFoundError("coupon not found")
+	}
+
+	if restErr := r.checkUniqueCode(coupon); restErr != nil {
+		return restErr
+	}
+
+	r.store.coupons[coupon.Id] = copyCoupon(coupon)
+	return nil
+}
+
+func (r *memoryCouponRepository) Delete(couponID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.coupons[couponID]; !ok {
+		return errors.NewNotFoundError("coupon not found")
+	}
+
+	delete(r.store.coupons, couponID)
+	return nil
+}
+
+// Redeem returns the same conflict as the MySQL one when the coupon does not
+// exist, since both only see that no row was updated.
+func (r *memoryCouponRepository) Redeem(couponID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	coupon, ok := r.store.coupons[couponID]
+	if !ok || (coupon.MaxUses > 0 && coupon.Uses >= coupon.MaxUses) {
+		return errors.NewConflictError(
+			fmt.Sprintf("coupon %d has reached its usage limit", couponID))
+	}
+
+	coupon.Uses++
+	r.store.coupons[couponID] = coupon
+	return nil
+}
+
+// checkUniqueCode emulates the unique key on the code of the coupon table. The
+// caller holds the lock.
+func (r *memoryCouponRepository) checkUniqueCode(coupon entities.Coupon) *errors.RestError {
+	for id, other := range r.store.coupons {
+		if id != coupon.Id && strings.EqualFold(other.Code, coupon.Code) {
+			return errors.NewConflictError(
+				fmt.Sprintf("coupon code %s already exists", coupon.Code))
+		}
+	}
+
+	return nil
+}
+
+func copyCoupon(coupon entities.Coupon) entities.Coupon {
+	coupon.BeerId = copyInt64(coupon.BeerId)
+	coupon.ValidFrom = copyTime(coupon.ValidFrom)
+	coupon.ValidUntil = copyTime(coupon.ValidUntil)
+	return coupon
+}
+
+// copyTime stores times in UTC, like nullableTime does for MySQL.
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	copied := value.UTC()
+	return &copied
+}
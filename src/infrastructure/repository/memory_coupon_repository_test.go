@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemorySaveCoupon_WhenCodeExistsWithOtherCase_ThenReturnConflictError(t *testing.T) {
+	repo := repository.NewMemoryCouponRepository(repository.NewMemoryStore())
+	repo.Save(entities.Coupon{Code: "SUMMER", Type: entities.PercentageCoupon})
+
+	id, err := repo.Save(entities.Coupon{Code: "summer", Type: entities.PercentageCoupon})
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, errors.NewConflictError("coupon code summer already exists"), err)
+}
+
+func Test_MemoryRedeemCoupon_WhenMaxUsesIsReached_ThenReturnConflictError(t *testing.T) {
+	repo := repository.NewMemoryCouponRepository(repository.NewMemoryStore())
+	id, _ := repo.Save(entities.Coupon{Code: "SUMMER", Type: entities.PercentageCoupon, MaxUses: 1})
+
+	firstErr := repo.Redeem(id)
+	secondErr := repo.Redeem(id)
+
+	assert.Nil(t, firstErr)
+	assert.Equal(t, errors.NewConflictError("coupon 1 has reached its usage limit"), secondErr)
+	coupon, _ := repo.GetByCode("summer")
+	assert.Equal(t, uint64(1), coupon.Uses)
+}
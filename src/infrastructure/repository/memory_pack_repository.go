@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type memoryPackRepository struct {
+	store *memoryStore
+}
+
+func NewMemoryPackRepository(store *memoryStore) *memoryPackRepository {
+	return &memoryPackRepository{
+		store: store,
+	}
+}
+
+func (r *memoryPackRepository) List() ([]entities.Pack, *errors.RestError) {
+	packs := r.list(func(pack entities.Pack) bool {
+		return true
+	})
+
+	sort.Slice(packs, func(i, j int) bool {
+		return packs[i].Id < packs[j].Id
+	})
+
+	return packs, nil
+}
+
+func (r *memoryPackRepository) ListByBeer(beerID int64) ([]entities.Pack, *errors.RestError) {
+	packs := r.list(func(pack entities.Pack) bool {
+		return pack.BeerId == beerID
+	})
+
+	sort.Slice(packs, func(i, j int) bool {
+		return packs[i].Size < packs[j].Size
+	})
+
+	return packs, nil
+}
+
+func (r *memoryPackRepository) GetByID(packID int64) (*entities.Pack, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pack, ok := r.store.packs[packID]
+	if !ok {
+		return nil, errors.NewNotFoundError("pack not found")
+	}
+
+	return &pack, nil
+}
+
+func (r *memoryPackRepository) GetByBeerAndSize(beerID int64, size uint64) (*entities.Pack, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, pack := range r.store.packs {
+		if pack.BeerId == beerID && pack.Size == size {
+			return &pack, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError("pack not found")
+}
+
+func (r *memoryPackRepository) Save(pack entities.Pack) (int64, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	pack.Id = r.store.nextPackID
+	if restErr := r.checkUniqueSize(pack); restErr != nil {
+		return 0, restErr
+	}
+
+	r.store.packs[pack.Id] = pack
+	r.store.nextPackID++
+
+	return pack.Id, nil
+}
+
+func (r *memoryPackRepository) Update(pack entities.Pack) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.packs[pack.Id]; !ok {
+		return errors.NewNotFoundError("pack not found")
+	}
+
+	if restErr := r.checkUniqueSize(pack); restErr != nil {
+		return restErr
+	}
+
+	r.store.packs[pack.Id] = pack
+	return nil
+}
+
+func (r *memoryPackRepository) Delete(packID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.packs[packID]; !ok {
+		return errors.NewNotFoundError("pack not found")
+	}
+
+	delete(r.store.packs, packID)
+	return nil
+}
+
+// checkUniqueSize emulates the unique key on beer_id and size of the pack
+// table. The caller holds the lock.
+func (r *memoryPackRepository) checkUniqueSize(pack entities.Pack) *errors.RestError {
+	for id, other := range r.store.packs {
+		if id != pack.Id && other.BeerId == pack.BeerId && other.Size == pack.Size {
+			return errors.NewConflictError(
+				fmt.Sprintf("beer %d already has a pack of %d", pack.BeerId, pack.Size))
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryPackRepository) list(match func(pack entities.Pack) bool) []entities.Pack {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	packs := make([]entities.Pack, 0)
+	for _, pack := range r.store.packs {
+		if match(pack) {
+			packs = append(packs, pack)
+		}
+	}
+
+	return packs
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type memoryPricingRuleRepository struct {
+	store *memoryStore
+}
+
+func NewMemoryPricingRuleRepository(store *memoryStore) *memoryPricingRuleRepository {
+	return &memoryPricingRuleRepository{
+		store: store,
+	}
+}
+
+func (r *memoryPricingRuleRepository) List() ([]entities.PricingRule, *errors.RestError) {
+	return r.list(func(rule entities.PricingRule) bool {
+		return true
+	}), nil
+}
+
+func (r *memoryPricingRuleRepository) ListByBeer(beerID int64) ([]entities.PricingRule, *errors.RestError) {
+	return r.list(func(rule entities.PricingRule) bool {
+		return rule.BeerId == nil || *rule.BeerId == beerID
+	}), nil
+}
+
+func (r *memoryPricingRuleRepository) GetByID(ruleID int64) (*entities.PricingRule, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rule, ok := r.store.pricingRules[ruleID]
+	if !ok {
+		return nil, errors.NewNotFoundError("pricing rule not found")
+	}
+
+	rule = copyPricingRule(rule)
+	return &rule, nil
+}
+
+func (r *memoryPricingRuleRepository) Save(rule entities.PricingRule) (int64, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	rule = copyPricingRule(rule)
+	rule.Id = r.store.nextPricingRuleID
+	r.store.pricingRules[rule.Id] = rule
+	r.store.nextPricingRuleID++
+
+	return rule.Id, nil
+}
+
+func (r *memoryPricingRuleRepository) Update(rule entities.PricingRule) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.pricingRules[rule.Id]; !ok {
+		return errors.NewNotFoundError("pricing rule not found")
+	}
+
+	r.store.pricingRules[rule.Id] = copyPricingRule(rule)
+	return nil
+}
+
+func (r *memoryPricingRuleRepository) Delete(ruleID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.pricingRules[ruleID]; !ok {
+		return errors.NewNotFoundError("pricing rule not found")
+	}
+
+	delete(r.store.pricingRules, ruleID)
+	return nil
+}
+
+func (r *memoryPricingRuleRepository) list(match func(rule entities.PricingRule) bool) []entities.PricingRule {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rules := make([]entities.PricingRule, 0)
+	for _, rule := range r.store.pricingRules {
+		if match(rule) {
+			rules = append(rules, copyPricingRule(rule))
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Id < rules[j].Id
+	})
+
+	return rules
+}
+
+func copyPricingRule(rule entities.PricingRule) entities.PricingRule {
+	rule.BeerId = copyInt64(rule.BeerId)
+	return rule
+}
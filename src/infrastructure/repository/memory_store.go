@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+)
+
+// memoryStore holds the tables of the in-memory repositories. The repositories
+// built on the same store share it like the MySQL ones share a database, so
+// deleting a beer also deletes its pricing rules, coupons and packs.
+type memoryStore struct {
+	mu sync.RWMutex
+
+	beers        map[int64]entities.Beer
+	pricingRules map[int64]entities.PricingRule
+	coupons      map[int64]entities.Coupon
+	taxRules     map[int64]entities.TaxRule
+	packs        map[int64]entities.Pack
+
+	// nextIDs emulate AUTO_INCREMENT, one per table.
+	nextBeerID        int64
+	nextPricingRuleID int64
+	nextCouponID      int64
+	nextTaxRuleID     int64
+	nextPackID        int64
+}
+
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{
+		beers:             make(map[int64]entities.Beer),
+		pricingRules:      make(map[int64]entities.PricingRule),
+		coupons:           make(map[int64]entities.Coupon),
+		taxRules:          make(map[int64]entities.TaxRule),
+		packs:             make(map[int64]entities.Pack),
+		nextBeerID:        1,
+		nextPricingRuleID: 1,
+		nextCouponID:      1,
+		nextTaxRuleID:     1,
+		nextPackID:        1,
+	}
+}
+
+// deleteBeerCascade deletes the rows referencing the beer, like the ON DELETE
+// CASCADE foreign keys of the MySQL tables. The caller holds the lock.
+func (s *memoryStore) deleteBeerCascade(beerID int64) {
+	for id, rule := range s.pricingRules {
+		if rule.BeerId != nil && *rule.BeerId == beerID {
+			delete(s.pricingRules, id)
+		}
+	}
+
+	for id, coupon := range s.coupons {
+		if coupon.BeerId != nil && *coupon.BeerId == beerID {
+			delete(s.coupons, id)
+		}
+	}
+
+	for id, pack := range s.packs {
+		if pack.BeerId == beerID {
+			delete(s.packs, id)
+		}
+	}
+}
+
+// copyInt64 returns a copy of value, so that stored rows do not share memory
+// with the callers.
+func copyInt64(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
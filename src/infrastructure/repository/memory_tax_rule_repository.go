@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+)
+
+type memoryTaxRuleRepository struct {
+	store *memoryStore
+}
+
+func NewMemoryTaxRuleRepository(store *memoryStore) *memoryTaxRuleRepository {
+	return &memoryTaxRuleRepository{
+		store: store,
+	}
+}
+
+func (r *memoryTaxRuleRepository) List() ([]entities.TaxRule, *errors.RestError) {
+	return r.list(func(rule entities.TaxRule) bool {
+		return true
+	}), nil
+}
+
+func (r *memoryTaxRuleRepository) ListByCountry(country string) ([]entities.TaxRule, *errors.RestError) {
+	return r.list(func(rule entities.TaxRule) bool {
+		return rule.Country == country
+	}), nil
+}
+
+func (r *memoryTaxRuleRepository) GetByID(ruleID int64) (*entities.TaxRule, *errors.RestError) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rule, ok := r.store.taxRules[ruleID]
+	if !ok {
+		return nil, errors.NewNotFoundError("tax rule not found")
+	}
+
+	return &rule, nil
+}
+
+func (r *memoryTaxRuleRepository) Save(rule entities.TaxRule) (int64, *errors.RestError) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	rule.Id = r.store.nextTaxRuleID
+	r.store.taxRules[rule.Id] = rule
+	r.store.nextTaxRuleID++
+
+	return rule.Id, nil
+}
+
+func (r *memoryTaxRuleRepository) Update(rule entities.TaxRule) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.taxRules[rule.Id]; !ok {
+		return errors.NewNotFoundError("tax rule not found")
+	}
+
+	r.store.taxRules[rule.Id] = rule
+	return nil
+}
+
+func (r *memoryTaxRuleRepository) Delete(ruleID int64) *errors.RestError {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.taxRules[ruleID]; !ok {
+		return errors.NewNotFoundError("tax rule not found")
+	}
+
+	delete(r.store.taxRules, ruleID)
+	return nil
+}
+
+func (r *memoryTaxRuleRepository) list(match func(rule entities.TaxRule) bool) []entities.TaxRule {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rules := make([]entities.TaxRule, 0)
+	for _, rule := range r.store.taxRules {
+		if match(rule) {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Id < rules[j].Id
+	})
+
+	return rules
+}
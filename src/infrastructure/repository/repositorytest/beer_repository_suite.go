@@ -0,0 +1,193 @@
+// Package repositorytest holds the conformance suites that every repository
+// implementation must pass, whatever its storage.
+package repositorytest
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/core/services"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const concurrentSaves = 20
+
+// RunBeerRepositorySuite runs the BeerRepository conformance suite. newRepository
+// is called once per case and must return a repository without beers.
+func RunBeerRepositorySuite(t *testing.T, newRepository func(t *testing.T) services.BeerRepository) {
+	t.Run("SaveThenGetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		beer := givenBeer(1, "Pilsen", "Colombia", 2500, "COP")
+
+		id, err := repo.Save(beer)
+		saved, getErr := repo.GetByID(id)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), id)
+		assert.Nil(t, getErr)
+		assertBeer(t, beer, saved)
+	})
+
+	t.Run("SaveWithoutIdThenGenerateId", func(t *testing.T) {
+		repo := newRepository(t)
+		repo.Save(givenBeer(7, "Pilsen", "Colombia", 2500, "COP"))
+		beer := givenBeer(0, "Club", "Colombia", 3000, "COP")
+
+		id, err := repo.Save(beer)
+		saved, getErr := repo.GetByID(id)
+
+		assert.Nil(t, err)
+		assert.Greater(t, id, int64(7))
+		assert.Nil(t, getErr)
+		beer.Id = id
+		assertBeer(t, beer, saved)
+	})
+
+	t.Run("SaveExistingIdThenConflict", func(t *testing.T) {
+		repo := newRepository(t)
+		repo.Save(givenBeer(1, "Pilsen", "Colombia", 2500, "COP"))
+
+		id, err := repo.Save(givenBeer(1, "Club", "Colombia", 3000, "COP"))
+		saved, _ := repo.GetByID(1)
+
+		assert.Equal(t, int64(0), id)
+		assert.Equal(t, errors.NewConflictError("beer id 1 already exists"), err)
+		assert.Equal(t, "Pilsen", saved.Name)
+	})
+
+	t.Run("GetByIDMissingThenNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		beer, err := repo.GetByID(1)
+
+		assert.Nil(t, beer)
+		assert.Equal(t, errors.NewNotFoundError("beer not found"), err)
+	})
+
+	t.Run("UpdateAndDeleteMissingThenNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+
+		updateErr := repo.Update(givenBeer(1, "Pilsen", "Colombia", 2500, "COP"))
+		deleteErr := repo.Delete(1)
+
+		assert.Equal(t, errors.NewNotFoundError("beer not found"), updateErr)
+		assert.Equal(t, errors.NewNotFoundError("beer not found"), deleteErr)
+	})
+
+	t.Run("UpdateThenGetByID", func(t *testing.T) {
+		repo := newRepository(t)
+		repo.Save(givenBeer(1, "Pilsen", "Colombia", 2500, "COP"))
+		beer := givenBeer(1, "Pilsen Light", "Colombia", 2700, "COP")
+
+		err := repo.Update(beer)
+		saved, _ := repo.GetByID(1)
+
+		assert.Nil(t, err)
+		assertBeer(t, beer, saved)
+	})
+
+	t.Run("DeleteThenNotFound", func(t *testing.T) {
+		repo := newRepository(t)
+		repo.Save(givenBeer(1, "Pilsen", "Colombia", 2500, "COP"))
+
+		err := repo.Delete(1)
+		beer, getErr := repo.GetByID(1)
+
+		assert.Nil(t, err)
+		assert.Nil(t, beer)
+		assert.Equal(t, errors.NewNotFoundError("beer not found"), getErr)
+	})
+
+	t.Run("ListAndCount", func(t *testing.T) {
+		repo := newRepository(t)
+		beers := []entities.Beer{
+			givenBeer(1, "Pilsen", "Colombia", 2500, "COP"),
+			givenBeer(2, "Corona", "Mexico", 2, "USD"),
+			givenBeer(3, "Aguila", "Colombia", 2500, "COP"),
+			givenBeer(4, "Club", "Colombia", 3000, "COP"),
+		}
+		for _, beer := range beers {
+			repo.Save(beer)
+		}
+		query := entities.NewBeerQuery()
+		query.Country = "colombia"
+		query.Sort = []entities.SortField{{Field: "price", Descending: true}, {Field: "name"}}
+		query.Limit = 2
+
+		listed, err := repo.List(query)
+		count, countErr := repo.Count(query)
+
+		assert.Nil(t, err)
+		assert.Len(t, listed, 2)
+		if len(listed) == 2 {
+			assertBeer(t, beers[3], &listed[0])
+			assertBeer(t, beers[2], &listed[1])
+		}
+		assert.Nil(t, countErr)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("ListWithoutBeersThenEmpty", func(t *testing.T) {
+		repo := newRepository(t)
+
+		listed, err := repo.List(entities.NewBeerQuery())
+
+		assert.Nil(t, err)
+		assert.Empty(t, listed)
+	})
+
+	t.Run("ConcurrentSavesThenUniqueIds", func(t *testing.T) {
+		repo := newRepository(t)
+		ids := make([]int64, concurrentSaves)
+		errs := make([]*errors.RestError, concurrentSaves)
+		var wg sync.WaitGroup
+
+		for i := 0; i < concurrentSaves; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ids[i], errs[i] = repo.Save(givenBeer(0, "Pilsen", "Colombia", 2500, "COP"))
+			}(i)
+		}
+		wg.Wait()
+
+		unique := make(map[int64]bool)
+		for i := range ids {
+			assert.Nil(t, errs[i])
+			unique[ids[i]] = true
+		}
+		assert.Len(t, unique, concurrentSaves)
+		count, _ := repo.Count(entities.NewBeerQuery())
+		assert.Equal(t, int64(concurrentSaves), count)
+	})
+}
+
+func givenBeer(id int64, name, country string, price int64, currency string) entities.Beer {
+	return entities.Beer{
+		Id:       id,
+		Name:     name,
+		Brewery:  "Bavaria",
+		Country:  country,
+		Price:    decimal.NewFromInt(price),
+		Currency: currency,
+	}
+}
+
+// assertBeer compares the prices by value, since each storage may return them
+// with a different scale.
+func assertBeer(t *testing.T, expected entities.Beer, actual *entities.Beer) {
+	t.Helper()
+	if !assert.NotNil(t, actual) {
+		return
+	}
+
+	assert.Equal(t, expected.Id, actual.Id)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Brewery, actual.Brewery)
+	assert.Equal(t, expected.Country, actual.Country)
+	assert.True(t, expected.Price.Equal(actual.Price), "price %s, want %s", actual.Price, expected.Price)
+	assert.Equal(t, expected.Currency, actual.Currency)
+}
@@ -0,0 +1,422 @@
+package repository
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const (
+	queryListBeers          = "SELECT id, name, brewery, country, price, currency FROM beer"
+	queryCountBeers         = "SELECT COUNT(*) FROM beer"
+	queryListBeerCurrencies = "SELECT DISTINCT currency FROM beer"
+	queryGetBeer            = "SELECT id, name, brewery, country, price, currency FROM beer WHERE id =?"
+	queryInsertBeer         = "INSERT INTO beer(id, name, brewery, country, price, currency) VALUES(?, ?, ?, ?, ?, ?);"
+	queryUpdateBeer         = "UPDATE beer SET name=?, brewery=?, country=?, price=?, currency=? WHERE id=?;"
+	queryDeleteBeer         = "DELETE FROM beer WHERE id=?;"
+
+	querySavepointBatchBeer           = "SAVEPOINT batch_beer;"
+	queryReleaseSavepointBatchBeer    = "RELEASE SAVEPOINT batch_beer;"
+	queryRollbackToSavepointBatchBeer = "ROLLBACK TO SAVEPOINT batch_beer;"
+)
+
+var beerSortColumns = map[string]string{
+	"id":       "id",
+	"name":     "name",
+	"brewery":  "brewery",
+	"country":  "country",
+	"price":    "price",
+	"currency": "currency",
+}
+
+type sqlBeerRepository struct {
+	db *sqlClient
+}
+
+func NewSqlBeerRepository(client *sql.DB, dialect db.Dialect) *sqlBeerRepository {
+	return &sqlBeerRepository{
+		db: newSqlClient(client, dialect),
+	}
+}
+
+func NewMySqlBeerRepository(client *sql.DB) *sqlBeerRepository {
+	return NewSqlBeerRepository(client, db.MySQL)
+}
+
+func (r *sqlBeerRepository) List(query entities.BeerQuery) ([]entities.Beer, *errors.RestError) {
+	conditions, args := buildBeerConditions(query)
+	stmt, err := r.db.Prepare(queryListBeers + conditions + buildBeerOrderBy(query.Sort) + " LIMIT ? OFFSET ?;")
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beers from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beers from database")
+	}
+	defer rows.Close()
+
+	beers := make([]entities.Beer, 0)
+	for rows.Next() {
+		var beer entities.Beer
+
+		if err := rows.Scan(&beer.Id, &beer.Name, &beer.Brewery, &beer.Country, &beer.Price, &beer.Currency); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get beers from database")
+		}
+
+		beers = append(beers, beer)
+	}
+
+	return beers, nil
+}
+
+// Stream calls fn with every beer matching the query filters, in the query
+// sort order and without pagination, as they are read from the database. It
+// stops at the first error returned by fn and returns it.
+func (r *sqlBeerRepository) Stream(query entities.BeerQuery, fn func(beer entities.Beer) *errors.RestError) *errors.RestError {
+	conditions, args := buildBeerConditions(query)
+	stmt, err := r.db.Prepare(queryListBeers + conditions + buildBeerOrderBy(query.Sort) + ";")
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to export beers from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return errors.NewInternalServerError("error trying to export beers from database")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var beer entities.Beer
+
+		if err := rows.Scan(&beer.Id, &beer.Name, &beer.Brewery, &beer.Country, &beer.Price, &beer.Currency); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return errors.NewInternalServerError("error trying to export beers from database")
+		}
+
+		if restErr := fn(beer); restErr != nil {
+			return restErr
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to iterate rows: %s", err))
+		return errors.NewInternalServerError("error trying to export beers from database")
+	}
+
+	return nil
+}
+
+// ListCurrencies returns the distinct currencies of the beers matching the
+// query filters.
+func (r *sqlBeerRepository) ListCurrencies(query entities.BeerQuery) ([]string, *errors.RestError) {
+	conditions, args := buildBeerConditions(query)
+	stmt, err := r.db.Prepare(queryListBeerCurrencies + conditions + ";")
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beer currencies from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beer currencies from database")
+	}
+	defer rows.Close()
+
+	currencies := make([]string, 0)
+	for rows.Next() {
+		var currency string
+
+		if err := rows.Scan(&currency); err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get beer currencies from database")
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to iterate rows: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beer currencies from database")
+	}
+
+	return currencies, nil
+}
+
+func (r *sqlBeerRepository) Count(query entities.BeerQuery) (int64, *errors.RestError) {
+	conditions, args := buildBeerConditions(query)
+	stmt, err := r.db.Prepare(queryCountBeers + conditions + ";")
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to count beers from database")
+	}
+	defer stmt.Close()
+
+	var total int64
+	if err := stmt.QueryRow(args...).Scan(&total); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return 0, errors.NewInternalServerError("error trying to count beers from database")
+	}
+
+	return total, nil
+}
+
+func (r *sqlBeerRepository) GetByID(beerID int64) (*entities.Beer, *errors.RestError) {
+	stmt, err := r.db.Prepare(queryGetBeer)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get beer from database")
+	}
+	defer stmt.Close()
+
+	bear := entities.Beer{}
+	result := stmt.QueryRow(beerID)
+	if getErr := result.Scan(&bear.Id, &bear.Name, &bear.Brewery, &bear.Country, &bear.Price, &bear.Currency); getErr != nil {
+		if genericerrors.Is(getErr, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("beer not found")
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", getErr))
+		return nil, errors.NewInternalServerError("error trying to get beer from database")
+	}
+
+	return &bear, nil
+}
+
+// Save inserts the beer and returns its id. When the beer has no id, the
+// database assigns one, through AUTO_INCREMENT in MySQL.
+func (r *sqlBeerRepository) Save(beer entities.Beer) (int64, *errors.RestError) {
+	stmt, err := r.db.PrepareInsert(queryInsertBeer)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save beer in database")
+	}
+	defer stmt.Close()
+
+	return r.saveBeer(stmt, beer)
+}
+
+// SaveBatch saves the beers in a single transaction and returns the result of
+// every beer in order. When atomic and any beer fails, the transaction is
+// rolled back and no result has an id.
+func (r *sqlBeerRepository) SaveBatch(beers []entities.Beer, atomic bool) ([]entities.BeerSaveResult, *errors.RestError) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to begin transaction: %s", err))
+		return nil, errors.NewInternalServerError("error trying to save beers in database")
+	}
+
+	stmt, err := tx.Prepare(r.db.insertQuery(queryInsertBeer))
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		rollback(tx)
+		return nil, errors.NewInternalServerError("error trying to save beers in database")
+	}
+	defer stmt.Close()
+
+	results := make([]entities.BeerSaveResult, len(beers))
+	failed := false
+	for i, beer := range beers {
+		results[i].Id, results[i].Err = r.saveBatchBeer(tx, stmt, beer)
+		failed = failed || results[i].Err != nil
+	}
+
+	if atomic && failed {
+		rollback(tx)
+		for i := range results {
+			results[i].Id = 0
+		}
+
+		return results, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to commit transaction: %s", err))
+		return nil, errors.NewInternalServerError("error trying to save beers in database")
+	}
+
+	return results, nil
+}
+
+// saveBatchBeer saves a beer of a batch. When a failed statement aborts the
+// transaction, the beer is saved in a savepoint rolled back on failure, so the
+// rest of the batch can still be saved.
+func (r *sqlBeerRepository) saveBatchBeer(tx *sql.Tx, stmt *sql.Stmt, beer entities.Beer) (int64, *errors.RestError) {
+	if !r.db.dialect.AbortsTransactionOnError() {
+		return r.saveBeer(stmt, beer)
+	}
+
+	if _, err := tx.Exec(querySavepointBatchBeer); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to create savepoint: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save beer in database")
+	}
+
+	beerID, restErr := r.saveBeer(stmt, beer)
+	endSavepoint := queryReleaseSavepointBatchBeer
+	if restErr != nil {
+		endSavepoint = queryRollbackToSavepointBatchBeer
+	}
+
+	if _, err := tx.Exec(endSavepoint); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to end savepoint: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save beer in database")
+	}
+
+	return beerID, restErr
+}
+
+func (r *sqlBeerRepository) saveBeer(stmt *sql.Stmt, beer entities.Beer) (int64, *errors.RestError) {
+	beerID := sql.NullInt64{Int64: beer.Id, Valid: beer.Id != 0}
+	insertedID, saveErr := r.db.insert(stmt, beerID, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency)
+	if saveErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", saveErr))
+		if r.db.dialect.IsDuplicateKey(saveErr) {
+			return 0, errors.NewConflictError(
+				fmt.Sprintf("beer id %d already exists", beer.Id))
+		}
+
+		return 0, errors.NewInternalServerError("error trying to save beer in database")
+	}
+
+	if beerID.Valid {
+		return beer.Id, nil
+	}
+
+	return insertedID, nil
+}
+
+func rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to rollback transaction: %s", err))
+	}
+}
+
+func (r *sqlBeerRepository) Update(beer entities.Beer) *errors.RestError {
+	stmt, err := r.db.Prepare(queryUpdateBeer)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to update beer in database")
+	}
+	defer stmt.Close()
+
+	result, updateErr := stmt.Exec(beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency, beer.Id)
+	if updateErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", updateErr))
+		return errors.NewInternalServerError("error trying to update beer in database")
+	}
+
+	return checkRowsAffected(result, "error trying to update beer in database", "beer not found")
+}
+
+func (r *sqlBeerRepository) Delete(beerID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryDeleteBeer)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to delete beer from database")
+	}
+	defer stmt.Close()
+
+	result, deleteErr := stmt.Exec(beerID)
+	if deleteErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", deleteErr))
+		return errors.NewInternalServerError("error trying to delete beer from database")
+	}
+
+	return checkRowsAffected(result, "error trying to delete beer from database", "beer not found")
+}
+
+// checkRowsAffected maps an UPDATE or DELETE that matched no row to a not found
+// error. The MySQL DSN enables clientFoundRows, so an UPDATE that leaves the row
+// unchanged still reports it as affected, like in the other dialects.
+func checkRowsAffected(result sql.Result, errorMessage, notFoundMessage string) *errors.RestError {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to get rows affected: %s", err))
+		return errors.NewInternalServerError(errorMessage)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewNotFoundError(notFoundMessage)
+	}
+
+	return nil
+}
+
+func buildBeerConditions(query entities.BeerQuery) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if query.Country != "" {
+		conditions = append(conditions, "country = ?")
+		args = append(args, query.Country)
+	}
+
+	if query.Brewery != "" {
+		conditions = append(conditions, "brewery = ?")
+		args = append(args, query.Brewery)
+	}
+
+	if query.Currency != "" {
+		conditions = append(conditions, "currency = ?")
+		args = append(args, query.Currency)
+	}
+
+	if query.MinPrice != nil {
+		conditions = append(conditions, "price >= ?")
+		args = append(args, *query.MinPrice)
+	}
+
+	if query.MaxPrice != nil {
+		conditions = append(conditions, "price <= ?")
+		args = append(args, *query.MaxPrice)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// buildBeerOrderBy always ends with the id column so that pagination is stable
+// when the requested sort fields have repeated values.
+func buildBeerOrderBy(sort []entities.SortField) string {
+	orderBy := make([]string, 0, len(sort)+1)
+	sortedByID := false
+
+	for _, sortField := range sort {
+		column, ok := beerSortColumns[sortField.Field]
+		if !ok {
+			continue
+		}
+
+		direction := "ASC"
+		if sortField.Descending {
+			direction = "DESC"
+		}
+
+		orderBy = append(orderBy, fmt.Sprintf("%s %s", column, direction))
+		sortedByID = sortedByID || column == "id"
+	}
+
+	if !sortedByID {
+		orderBy = append(orderBy, "id ASC")
+	}
+
+	return " ORDER BY " + strings.Join(orderBy, ", ")
+}
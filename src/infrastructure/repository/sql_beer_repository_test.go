@@ -0,0 +1,635 @@
+package repository_test
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	queryListBeersTest          = "SELECT id, name, brewery, country, price, currency FROM beer ORDER BY id ASC LIMIT ? OFFSET ?;"
+	queryCountBeersTest         = "SELECT COUNT(*) FROM beer;"
+	queryStreamBeersTest        = "SELECT id, name, brewery, country, price, currency FROM beer ORDER BY id ASC;"
+	queryListBeerCurrenciesTest = "SELECT DISTINCT currency FROM beer;"
+	queryGetBeerTest            = "SELECT id, name, brewery, country, price, currency FROM beer WHERE id =?"
+	queryInsertBeerTest         = "INSERT INTO beer(id, name, brewery, country, price, currency) VALUES(?, ?, ?, ?, ?, ?);"
+	queryUpdateBeerTest         = "UPDATE beer SET name=?, brewery=?, country=?, price=?, currency=? WHERE id=?;"
+	queryDeleteBeerTest         = "DELETE FROM beer WHERE id=?;"
+
+	queryInsertBeerPostgresTest           = "INSERT INTO beer(id, name, brewery, country, price, currency) VALUES($1, $2, $3, $4, $5, $6) RETURNING id;"
+	querySavepointBatchBeerTest           = "SAVEPOINT batch_beer;"
+	queryReleaseSavepointBatchBeerTest    = "RELEASE SAVEPOINT batch_beer;"
+	queryRollbackToSavepointBatchBeerTest = "ROLLBACK TO SAVEPOINT batch_beer;"
+)
+
+func Test_List_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beers from database")
+	mock.ExpectPrepare(queryListBeersTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.List(entities.NewBeerQuery())
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_List_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beers from database")
+	mock.ExpectPrepare(queryListBeersTest)
+	mock.ExpectQuery(queryListBeersTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.List(entities.NewBeerQuery())
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_List_WhenScanRowsFail_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(beer.Id, beer.Name, beer.Brewery, beer.Country, "invalid", beer.Currency)
+	expectedError := errors.NewInternalServerError("error trying to get beers from database")
+	mock.ExpectPrepare(queryListBeersTest)
+	mock.ExpectQuery(queryListBeersTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.List(entities.NewBeerQuery())
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_List_WhenQueryIsExecutedSuccessfully_ThenReturnBeers(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency)
+	expectedBeer := []entities.Beer{*beer}
+	mock.ExpectPrepare(queryListBeersTest)
+	mock.ExpectQuery(queryListBeersTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.List(entities.NewBeerQuery())
+
+	assert.Equal(t, expectedBeer, beers)
+	assert.Nil(t, err)
+}
+
+func Test_List_WhenQueryHasFiltersAndSort_ThenBuildQueryWithConditions(t *testing.T) {
+	beer := givenBeer()
+	minPrice := decimal.NewFromInt(1000)
+	maxPrice := decimal.NewFromInt(3000)
+	query := entities.NewBeerQuery()
+	query.Limit = 10
+	query.Offset = 20
+	query.Country = beer.Country
+	query.Currency = beer.Currency
+	query.MinPrice = &minPrice
+	query.MaxPrice = &maxPrice
+	query.Sort = []entities.SortField{{Field: "price"}, {Field: "name", Descending: true}}
+	expectedQuery := "SELECT id, name, brewery, country, price, currency FROM beer " +
+		"WHERE country = ? AND currency = ? AND price >= ? AND price <= ? " +
+		"ORDER BY price ASC, name DESC, id ASC LIMIT ? OFFSET ?;"
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency)
+	mock.ExpectPrepare(expectedQuery)
+	mock.ExpectQuery(expectedQuery).WithArgs(beer.Country, beer.Currency, minPrice, maxPrice, 10, 20).
+		WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.List(query)
+
+	assert.Equal(t, []entities.Beer{*beer}, beers)
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Stream_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to export beers from database")
+	mock.ExpectPrepare(queryStreamBeersTest)
+	mock.ExpectQuery(queryStreamBeersTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Stream(entities.NewBeerQuery(), nil)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Stream_WhenFnFail_ThenStopAndReturnItsError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		AddRow(2, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency)
+	expectedError := errors.NewInternalServerError("some error")
+	mock.ExpectPrepare(queryStreamBeersTest)
+	mock.ExpectQuery(queryStreamBeersTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	calls := 0
+	err := repo.Stream(entities.NewBeerQuery(), func(beer entities.Beer) *errors.RestError {
+		calls++
+		return expectedError
+	})
+
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, 1, calls)
+}
+
+func Test_Stream_WhenQueryHasFiltersAndLimit_ThenStreamEveryMatchingBeer(t *testing.T) {
+	beer := givenBeer()
+	query := entities.NewBeerQuery()
+	query.Limit = 10
+	query.Country = beer.Country
+	query.Sort = []entities.SortField{{Field: "name", Descending: true}}
+	expectedQuery := "SELECT id, name, brewery, country, price, currency FROM beer " +
+		"WHERE country = ? ORDER BY name DESC, id ASC;"
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency)
+	mock.ExpectPrepare(expectedQuery)
+	mock.ExpectQuery(expectedQuery).WithArgs(beer.Country).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	var beers []entities.Beer
+	err := repo.Stream(query, func(beer entities.Beer) *errors.RestError {
+		beers = append(beers, beer)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.Beer{*beer}, beers)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_ListCurrencies_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beer currencies from database")
+	mock.ExpectPrepare(queryListBeerCurrenciesTest)
+	mock.ExpectQuery(queryListBeerCurrenciesTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	currencies, err := repo.ListCurrencies(entities.NewBeerQuery())
+
+	assert.Nil(t, currencies)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ListCurrencies_WhenIterateRowsFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	rowErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beer currencies from database")
+	queryRows := mock.NewRows([]string{"currency"}).AddRow("COP").AddRow("USD").RowError(1, rowErr)
+	mock.ExpectPrepare(queryListBeerCurrenciesTest)
+	mock.ExpectQuery(queryListBeerCurrenciesTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	currencies, err := repo.ListCurrencies(entities.NewBeerQuery())
+
+	assert.Nil(t, currencies)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ListCurrencies_WhenQueryIsExecutedSuccessfully_ThenReturnCurrencies(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{"currency"}).AddRow("COP").AddRow("USD")
+	mock.ExpectPrepare(queryListBeerCurrenciesTest)
+	mock.ExpectQuery(queryListBeerCurrenciesTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	currencies, err := repo.ListCurrencies(entities.NewBeerQuery())
+
+	assert.Equal(t, []string{"COP", "USD"}, currencies)
+	assert.Nil(t, err)
+}
+
+func Test_Count_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to count beers from database")
+	mock.ExpectPrepare(queryCountBeersTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	total, err := repo.Count(entities.NewBeerQuery())
+
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Count_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to count beers from database")
+	mock.ExpectPrepare(queryCountBeersTest)
+	mock.ExpectQuery(queryCountBeersTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	total, err := repo.Count(entities.NewBeerQuery())
+
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Count_WhenQueryHasFilters_ThenReturnTotal(t *testing.T) {
+	query := entities.NewBeerQuery()
+	query.Brewery = "Bavaria"
+	expectedQuery := "SELECT COUNT(*) FROM beer WHERE brewery = ?;"
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(expectedQuery)
+	mock.ExpectQuery(expectedQuery).WithArgs(query.Brewery).
+		WillReturnRows(mock.NewRows([]string{"count"}).AddRow(42))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	total, err := repo.Count(query)
+
+	assert.Equal(t, int64(42), total)
+	assert.Nil(t, err)
+}
+
+func Test_GetByID_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beer from database")
+	mock.ExpectPrepare(queryListBeersTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.GetByID(id)
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetByID_WhenExecuteQueryFail_ThenReturnNotFoundError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := sql.ErrNoRows
+	expectedError := errors.NewNotFoundError("beer not found")
+	mock.ExpectPrepare(queryGetBeerTest)
+	mock.ExpectQuery(queryGetBeerTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.GetByID(id)
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetByID_WhenExecuteQueryFail_ThenReturnInternalServerError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to get beer from database")
+	mock.ExpectPrepare(queryGetBeerTest)
+	mock.ExpectQuery(queryGetBeerTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.GetByID(id)
+
+	assert.Nil(t, beers)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetByID_WhenQueryIsExecutedSuccessfully_ThenReturnBeer(t *testing.T) {
+	id := int64(1)
+	expectedBeer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "name", "brewery", "country", "price", "currency",
+	}).AddRow(expectedBeer.Id, expectedBeer.Name, expectedBeer.Brewery, expectedBeer.Country, expectedBeer.Price, expectedBeer.Currency)
+	mock.ExpectPrepare(queryGetBeerTest)
+	mock.ExpectQuery(queryGetBeerTest).WillReturnRows(queryRows)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	beers, err := repo.GetByID(id)
+
+	assert.Equal(t, expectedBeer, beers)
+	assert.Nil(t, err)
+}
+
+func Test_Save_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to save beer in database")
+	mock.ExpectPrepare(queryInsertBeerTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Save_WhenExecuteQueryFailAndCastingErrorToMySQLErrorFail_ThenReturnInternalServerError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to save beer in database")
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectQuery(queryInsertBeerTest).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Save_WhenExecuteQueryFail_ThenReturnConflictError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := &mysql.MySQLError{Number: 1062, Message: "duplicate entry"}
+	expectedError := errors.NewConflictError("beer id 1 already exists")
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Save_WhenExecuteQueryFail_ThenReturnInternalServerError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := &mysql.MySQLError{Number: 1064, Message: "syntax error"}
+	expectedError := errors.NewInternalServerError("error trying to save beer in database")
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Save_WhenQueryIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, beer.Id, id)
+	assert.Nil(t, err)
+}
+
+func Test_SaveBatch_WhenBeginFail_ThenReturnInternalServerError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewInternalServerError("error trying to save beers in database")
+	mock.ExpectBegin().WillReturnError(genericerrors.New("some error"))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	results, err := repo.SaveBatch([]entities.Beer{*givenBeer()}, true)
+
+	assert.Nil(t, results)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_SaveBatch_WhenAtomicAndOneBeerFail_ThenRollbackAndReturnNoIds(t *testing.T) {
+	beer := givenBeer()
+	duplicate := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(duplicate.Id, duplicate.Name, duplicate.Brewery, duplicate.Country, duplicate.Price, duplicate.Currency).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "duplicate entry"})
+	mock.ExpectRollback()
+	repo := repository.NewMySqlBeerRepository(db)
+
+	results, err := repo.SaveBatch([]entities.Beer{*beer, *duplicate}, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{},
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+	}, results)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_SaveBatch_WhenNotAtomicAndOneBeerFail_ThenCommitTheOthers(t *testing.T) {
+	beer := givenBeer()
+	beer.Id = 0
+	duplicate := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(nil, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(duplicate.Id, duplicate.Name, duplicate.Brewery, duplicate.Country, duplicate.Price, duplicate.Currency).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "duplicate entry"})
+	mock.ExpectCommit()
+	repo := repository.NewMySqlBeerRepository(db)
+
+	results, err := repo.SaveBatch([]entities.Beer{*beer, *duplicate}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{Id: 7},
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+	}, results)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_SaveBatch_WhenDialectIsPostgresAndBeerInTheMiddleFail_ThenSaveTheOthersInSavepoints(t *testing.T) {
+	beer := givenBeer()
+	duplicate := givenBeer()
+	lastBeer := givenBeer()
+	lastBeer.Id = 3
+	client, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectBegin()
+	mock.ExpectPrepare(queryInsertBeerPostgresTest)
+	mock.ExpectExec(querySavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryInsertBeerPostgresTest).WithArgs(beer.Id, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(queryReleaseSavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(querySavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryInsertBeerPostgresTest).WithArgs(duplicate.Id, duplicate.Name, duplicate.Brewery, duplicate.Country, duplicate.Price, duplicate.Currency).
+		WillReturnError(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
+	mock.ExpectExec(queryRollbackToSavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(querySavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(queryInsertBeerPostgresTest).WithArgs(lastBeer.Id, lastBeer.Name, lastBeer.Brewery, lastBeer.Country, lastBeer.Price, lastBeer.Currency).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(queryReleaseSavepointBatchBeerTest).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	repo := repository.NewSqlBeerRepository(client, db.Postgres)
+
+	results, err := repo.SaveBatch([]entities.Beer{*beer, *duplicate, *lastBeer}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{Id: 1},
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+		{Id: 3},
+	}, results)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_Save_WhenBeerHasNoId_ThenReturnGeneratedId(t *testing.T) {
+	beer := givenBeer()
+	beer.Id = 0
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryInsertBeerTest)
+	mock.ExpectExec(queryInsertBeerTest).WithArgs(nil, beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	id, err := repo.Save(*beer)
+
+	assert.Equal(t, int64(7), id)
+	assert.Nil(t, err)
+}
+
+func Test_Update_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to update beer in database")
+	mock.ExpectPrepare(queryUpdateBeerTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Update(*beer)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Update_WhenExecuteQueryFail_ThenReturnInternalServerError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to update beer in database")
+	mock.ExpectPrepare(queryUpdateBeerTest)
+	mock.ExpectExec(queryUpdateBeerTest).WithArgs(beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency, beer.Id).
+		WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Update(*beer)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Update_WhenNoRowIsAffected_ThenReturnNotFoundError(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("beer not found")
+	mock.ExpectPrepare(queryUpdateBeerTest)
+	mock.ExpectExec(queryUpdateBeerTest).WithArgs(beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency, beer.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Update(*beer)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Update_WhenQueryIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+	beer := givenBeer()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryUpdateBeerTest)
+	mock.ExpectExec(queryUpdateBeerTest).WithArgs(beer.Name, beer.Brewery, beer.Country, beer.Price, beer.Currency, beer.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Update(*beer)
+
+	assert.Nil(t, err)
+}
+
+func Test_Delete_WhenPrepareStmtFail_ThenReturnError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	prepareErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to delete beer from database")
+	mock.ExpectPrepare(queryDeleteBeerTest).WillReturnError(prepareErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Delete(id)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Delete_WhenExecuteQueryFail_ThenReturnInternalServerError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryErr := genericerrors.New("some error")
+	expectedError := errors.NewInternalServerError("error trying to delete beer from database")
+	mock.ExpectPrepare(queryDeleteBeerTest)
+	mock.ExpectExec(queryDeleteBeerTest).WithArgs(id).WillReturnError(queryErr)
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Delete(id)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Delete_WhenNoRowIsAffected_ThenReturnNotFoundError(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("beer not found")
+	mock.ExpectPrepare(queryDeleteBeerTest)
+	mock.ExpectExec(queryDeleteBeerTest).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Delete(id)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_Delete_WhenQueryIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+	id := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryDeleteBeerTest)
+	mock.ExpectExec(queryDeleteBeerTest).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	repo := repository.NewMySqlBeerRepository(db)
+
+	err := repo.Delete(id)
+
+	assert.Nil(t, err)
+}
+
+func givenBeer() *entities.Beer {
+	return &entities.Beer{
+		Id:       1,
+		Name:     "Pilsen",
+		Brewery:  "Bavaria",
+		Country:  "Colombia",
+		Price:    decimal.NewFromInt(2500),
+		Currency: "COP",
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+// sqlClient runs the queries of the SQL repositories, written for MySQL with ?
+// placeholders, in the dialect of the database.
+type sqlClient struct {
+	*sql.DB
+	dialect db.Dialect
+}
+
+func newSqlClient(client *sql.DB, dialect db.Dialect) *sqlClient {
+	return &sqlClient{
+		DB:      client,
+		dialect: dialect,
+	}
+}
+
+func (c *sqlClient) Prepare(query string) (*sql.Stmt, error) {
+	return c.DB.Prepare(c.dialect.Rebind(query))
+}
+
+// insertQuery rebinds an INSERT, adding a RETURNING clause when the dialect
+// gets the new id with it.
+func (c *sqlClient) insertQuery(query string) string {
+	if c.dialect.ReturnsInsertID() {
+		query = strings.TrimSuffix(query, ";") + " RETURNING id;"
+	}
+
+	return c.dialect.Rebind(query)
+}
+
+func (c *sqlClient) PrepareInsert(query string) (*sql.Stmt, error) {
+	return c.DB.Prepare(c.insertQuery(query))
+}
+
+// insert runs a statement prepared from insertQuery and returns the id of the
+// new row.
+func (c *sqlClient) insert(stmt *sql.Stmt, args ...interface{}) (int64, error) {
+	if c.dialect.ReturnsInsertID() {
+		var insertedID int64
+		err := stmt.QueryRow(args...).Scan(&insertedID)
+		return insertedID, err
+	}
+
+	result, err := stmt.Exec(args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const (
+	couponColumns      = "id, code, type, percentage, amount, currency, valid_from, valid_until, max_uses, uses, beer_id, brewery, country"
+	queryListCoupons   = "SELECT " + couponColumns + " FROM coupon ORDER BY id ASC;"
+	queryGetCoupon     = "SELECT " + couponColumns + " FROM coupon WHERE id = ?;"
+	queryGetCouponCode = "SELECT " + couponColumns + " FROM coupon WHERE code = ?;"
+	queryInsertCoupon  = "INSERT INTO coupon(code, type, percentage, amount, currency, valid_from, valid_until, max_uses, uses, beer_id, brewery, country) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	queryUpdateCoupon  = "UPDATE coupon SET code=?, type=?, percentage=?, amount=?, currency=?, valid_from=?, valid_until=?, max_uses=?, uses=?, beer_id=?, brewery=?, country=? WHERE id=?;"
+	queryDeleteCoupon  = "DELETE FROM coupon WHERE id=?;"
+	queryRedeemCoupon  = "UPDATE coupon SET uses = uses + 1 WHERE id = ? AND (max_uses = 0 OR uses < max_uses);"
+)
+
+type sqlCouponRepository struct {
+	db *sqlClient
+}
+
+func NewSqlCouponRepository(client *sql.DB, dialect db.Dialect) *sqlCouponRepository {
+	return &sqlCouponRepository{
+		db: newSqlClient(client, dialect),
+	}
+}
+
+func NewMySqlCouponRepository(client *sql.DB) *sqlCouponRepository {
+	return NewSqlCouponRepository(client, db.MySQL)
+}
+
+func (r *sqlCouponRepository) List() ([]entities.Coupon, *errors.RestError) {
+	stmt, err := r.db.Prepare(queryListCoupons)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get coupons from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get coupons from database")
+	}
+	defer rows.Close()
+
+	coupons := make([]entities.Coupon, 0)
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get coupons from database")
+		}
+
+		coupons = append(coupons, *coupon)
+	}
+
+	return coupons, nil
+}
+
+func (r *sqlCouponRepository) GetByID(couponID int64) (*entities.Coupon, *errors.RestError) {
+	return r.get(queryGetCoupon, couponID)
+}
+
+func (r *sqlCouponRepository) GetByCode(code string) (*entities.Coupon, *errors.RestError) {
+	return r.get(queryGetCouponCode, code)
+}
+
+func (r *sqlCouponRepository) get(query string, arg interface{}) (*entities.Coupon, *errors.RestError) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get coupon from database")
+	}
+	defer stmt.Close()
+
+	coupon, getErr := scanCoupon(stmt.QueryRow(arg))
+	if getErr != nil {
+		if genericerrors.Is(getErr, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("coupon not found")
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", getErr))
+		return nil, errors.NewInternalServerError("error trying to get coupon from database")
+	}
+
+	return coupon, nil
+}
+
+func (r *sqlCouponRepository) Save(coupon entities.Coupon) (int64, *errors.RestError) {
+	stmt, err := r.db.PrepareInsert(queryInsertCoupon)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save coupon in database")
+	}
+	defer stmt.Close()
+
+	insertedID, saveErr := r.db.insert(stmt, couponArgs(coupon)...)
+	if saveErr != nil {
+		return 0, r.writeError(saveErr, coupon, "error trying to save coupon in database")
+	}
+
+	return insertedID, nil
+}
+
+func (r *sqlCouponRepository) Update(coupon entities.Coupon) *errors.RestError {
+	stmt, err := r.db.Prepare(queryUpdateCoupon)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to update coupon in database")
+	}
+	defer stmt.Close()
+
+	result, updateErr := stmt.Exec(append(couponArgs(coupon), coupon.Id)...)
+	if updateErr != nil {
+		return r.writeError(updateErr, coupon, "error trying to update coupon in database")
+	}
+
+	return checkRowsAffected(result, "error trying to update coupon in database", "coupon not found")
+}
+
+func (r *sqlCouponRepository) Delete(couponID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryDeleteCoupon)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to delete coupon from database")
+	}
+	defer stmt.Close()
+
+	result, deleteErr := stmt.Exec(couponID)
+	if deleteErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", deleteErr))
+		return errors.NewInternalServerError("error trying to delete coupon from database")
+	}
+
+	return checkRowsAffected(result, "error trying to delete coupon from database", "coupon not found")
+}
+
+// Redeem increments the uses of the coupon in a single statement, so that
+// concurrent redemptions can not go over MaxUses.
+func (r *sqlCouponRepository) Redeem(couponID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryRedeemCoupon)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to redeem coupon in database")
+	}
+	defer stmt.Close()
+
+	result, redeemErr := stmt.Exec(couponID)
+	if redeemErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", redeemErr))
+		return errors.NewInternalServerError("error trying to redeem coupon in database")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to get rows affected: %s", err))
+		return errors.NewInternalServerError("error trying to redeem coupon in database")
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewConflictError(
+			fmt.Sprintf("coupon %d has reached its usage limit", couponID))
+	}
+
+	return nil
+}
+
+func scanCoupon(row rowScanner) (*entities.Coupon, error) {
+	var coupon entities.Coupon
+	var validFrom, validUntil sql.NullTime
+	var beerID sql.NullInt64
+
+	if err := row.Scan(&coupon.Id, &coupon.Code, &coupon.Type, &coupon.Percentage, &coupon.Amount, &coupon.Currency,
+		&validFrom, &validUntil, &coupon.MaxUses, &coupon.Uses, &beerID, &coupon.Brewery, &coupon.Country); err != nil {
+		return nil, err
+	}
+
+	if validFrom.Valid {
+		coupon.ValidFrom = &validFrom.Time
+	}
+
+	if validUntil.Valid {
+		coupon.ValidUntil = &validUntil.Time
+	}
+
+	if beerID.Valid {
+		coupon.BeerId = &beerID.Int64
+	}
+
+	return &coupon, nil
+}
+
+func couponArgs(coupon entities.Coupon) []interface{} {
+	return []interface{}{
+		coupon.Code, coupon.Type, coupon.Percentage, coupon.Amount, coupon.Currency,
+		nullableTime(coupon.ValidFrom), nullableTime(coupon.ValidUntil),
+		coupon.MaxUses, coupon.Uses, nullableBeerID(coupon.BeerId), coupon.Brewery, coupon.Country,
+	}
+}
+
+func (r *sqlCouponRepository) writeError(err error, coupon entities.Coupon, errorMessage string) *errors.RestError {
+	logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+	if r.db.dialect.IsDuplicateKey(err) {
+		return errors.NewConflictError(
+			fmt.Sprintf("coupon code %s already exists", coupon.Code))
+	}
+
+	return errors.NewInternalServerError(errorMessage)
+}
+
+// nullableTime stores times in UTC, matching the loc of the MySQL DSN.
+func nullableTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: value.UTC(), Valid: true}
+}
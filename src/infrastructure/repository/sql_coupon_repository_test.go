@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/go-sql-driver/mysql"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	couponColumnsTest      = "id, code, type, percentage, amount, currency, valid_from, valid_until, max_uses, uses, beer_id, brewery, country"
+	queryGetCouponCodeTest = "SELECT " + couponColumnsTest + " FROM coupon WHERE code = ?;"
+	queryInsertCouponTest  = "INSERT INTO coupon(code, type, percentage, amount, currency, valid_from, valid_until, max_uses, uses, beer_id, brewery, country) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);"
+	queryRedeemCouponTest  = "UPDATE coupon SET uses = uses + 1 WHERE id = ? AND (max_uses = 0 OR uses < max_uses);"
+)
+
+func Test_GetCouponByCode_WhenCouponDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("coupon not found")
+	mock.ExpectPrepare(queryGetCouponCodeTest)
+	mock.ExpectQuery(queryGetCouponCodeTest).WithArgs("SUMMER").WillReturnError(sql.ErrNoRows)
+	repo := repository.NewMySqlCouponRepository(db)
+
+	coupon, err := repo.GetByCode("SUMMER")
+
+	assert.Nil(t, coupon)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_GetCouponByCode_WhenQueryIsExecutedSuccessfully_ThenReturnCoupon(t *testing.T) {
+	validUntil := time.Date(2021, 12, 31, 23, 59, 59, 0, time.UTC)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows([]string{
+		"id", "code", "type", "percentage", "amount", "currency", "valid_from", "valid_until",
+		"max_uses", "uses", "beer_id", "brewery", "country",
+	}).AddRow(2, "SUMMER", entities.PercentageCoupon, "10.00", "0.00", "", nil, validUntil, 100, 3, nil, "Bavaria", "")
+	mock.ExpectPrepare(queryGetCouponCodeTest)
+	mock.ExpectQuery(queryGetCouponCodeTest).WithArgs("SUMMER").WillReturnRows(queryRows)
+	repo := repository.NewMySqlCouponRepository(db)
+
+	coupon, err := repo.GetByCode("SUMMER")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), coupon.Id)
+	assert.True(t, decimal.NewFromInt(10).Equal(coupon.Percentage))
+	assert.Nil(t, coupon.ValidFrom)
+	assert.Equal(t, validUntil, *coupon.ValidUntil)
+	assert.Equal(t, uint64(3), coupon.Uses)
+	assert.Nil(t, coupon.BeerId)
+	assert.Equal(t, "Bavaria", coupon.Brewery)
+}
+
+func Test_SaveCoupon_WhenCodeAlreadyExists_ThenReturnConflictError(t *testing.T) {
+	coupon := entities.Coupon{Code: "SUMMER", Type: entities.PercentageCoupon, Percentage: decimal.NewFromInt(10)}
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewConflictError("coupon code SUMMER already exists")
+	mock.ExpectPrepare(queryInsertCouponTest)
+	mock.ExpectExec(queryInsertCouponTest).
+		WithArgs(coupon.Code, coupon.Type, coupon.Percentage, coupon.Amount, coupon.Currency, sql.NullTime{}, sql.NullTime{},
+			coupon.MaxUses, coupon.Uses, sql.NullInt64{}, coupon.Brewery, coupon.Country).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	repo := repository.NewMySqlCouponRepository(db)
+
+	couponID, err := repo.Save(coupon)
+
+	assert.Equal(t, int64(0), couponID)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_RedeemCoupon_WhenExecuteQueryFail_ThenReturnInternalServerError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewInternalServerError("error trying to redeem coupon in database")
+	mock.ExpectPrepare(queryRedeemCouponTest)
+	mock.ExpectExec(queryRedeemCouponTest).WithArgs(int64(2)).WillReturnError(genericerrors.New("some error"))
+	repo := repository.NewMySqlCouponRepository(db)
+
+	err := repo.Redeem(2)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_RedeemCoupon_WhenNoRowIsAffected_ThenReturnConflictError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewConflictError("coupon 2 has reached its usage limit")
+	mock.ExpectPrepare(queryRedeemCouponTest)
+	mock.ExpectExec(queryRedeemCouponTest).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := repository.NewMySqlCouponRepository(db)
+
+	err := repo.Redeem(2)
+
+	assert.Equal(t, expectedError, err)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const (
+	queryListPacks            = "SELECT id, beer_id, name, size, surcharge, currency FROM pack ORDER BY id ASC;"
+	queryListPacksByBeer      = "SELECT id, beer_id, name, size, surcharge, currency FROM pack WHERE beer_id = ? ORDER BY size ASC;"
+	queryGetPack              = "SELECT id, beer_id, name, size, surcharge, currency FROM pack WHERE id = ?;"
+	queryGetPackByBeerAndSize = "SELECT id, beer_id, name, size, surcharge, currency FROM pack WHERE beer_id = ? AND size = ?;"
+	queryInsertPack           = "INSERT INTO pack(beer_id, name, size, surcharge, currency) VALUES(?, ?, ?, ?, ?);"
+	queryUpdatePack           = "UPDATE pack SET beer_id=?, name=?, size=?, surcharge=?, currency=? WHERE id=?;"
+	queryDeletePack           = "DELETE FROM pack WHERE id=?;"
+)
+
+type sqlPackRepository struct {
+	db *sqlClient
+}
+
+func NewSqlPackRepository(client *sql.DB, dialect db.Dialect) *sqlPackRepository {
+	return &sqlPackRepository{
+		db: newSqlClient(client, dialect),
+	}
+}
+
+func NewMySqlPackRepository(client *sql.DB) *sqlPackRepository {
+	return NewSqlPackRepository(client, db.MySQL)
+}
+
+func (r *sqlPackRepository) List() ([]entities.Pack, *errors.RestError) {
+	return r.list(queryListPacks)
+}
+
+func (r *sqlPackRepository) ListByBeer(beerID int64) ([]entities.Pack, *errors.RestError) {
+	return r.list(queryListPacksByBeer, beerID)
+}
+
+func (r *sqlPackRepository) list(query string, args ...interface{}) ([]entities.Pack, *errors.RestError) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get packs from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get packs from database")
+	}
+	defer rows.Close()
+
+	packs := make([]entities.Pack, 0)
+	for rows.Next() {
+		pack, err := scanPack(rows)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get packs from database")
+		}
+
+		packs = append(packs, *pack)
+	}
+
+	return packs, nil
+}
+
+func (r *sqlPackRepository) GetByID(packID int64) (*entities.Pack, *errors.RestError) {
+	return r.get(queryGetPack, packID)
+}
+
+func (r *sqlPackRepository) GetByBeerAndSize(beerID int64, size uint64) (*entities.Pack, *errors.RestError) {
+	return r.get(queryGetPackByBeerAndSize, beerID, size)
+}
+
+func (r *sqlPackRepository) get(query string, args ...interface{}) (*entities.Pack, *errors.RestError) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get pack from database")
+	}
+	defer stmt.Close()
+
+	pack, getErr := scanPack(stmt.QueryRow(args...))
+	if getErr != nil {
+		if genericerrors.Is(getErr, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("pack not found")
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", getErr))
+		return nil, errors.NewInternalServerError("error trying to get pack from database")
+	}
+
+	return pack, nil
+}
+
+func (r *sqlPackRepository) Save(pack entities.Pack) (int64, *errors.RestError) {
+	stmt, err := r.db.PrepareInsert(queryInsertPack)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save pack in database")
+	}
+	defer stmt.Close()
+
+	insertedID, saveErr := r.db.insert(stmt, pack.BeerId, pack.Name, pack.Size, pack.Surcharge, pack.Currency)
+	if saveErr != nil {
+		return 0, r.writeError(saveErr, pack, "error trying to save pack in database")
+	}
+
+	return insertedID, nil
+}
+
+func (r *sqlPackRepository) Update(pack entities.Pack) *errors.RestError {
+	stmt, err := r.db.Prepare(queryUpdatePack)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to update pack in database")
+	}
+	defer stmt.Close()
+
+	result, updateErr := stmt.Exec(pack.BeerId, pack.Name, pack.Size, pack.Surcharge, pack.Currency, pack.Id)
+	if updateErr != nil {
+		return r.writeError(updateErr, pack, "error trying to update pack in database")
+	}
+
+	return checkRowsAffected(result, "error trying to update pack in database", "pack not found")
+}
+
+func (r *sqlPackRepository) Delete(packID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryDeletePack)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to delete pack from database")
+	}
+	defer stmt.Close()
+
+	result, deleteErr := stmt.Exec(packID)
+	if deleteErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", deleteErr))
+		return errors.NewInternalServerError("error trying to delete pack from database")
+	}
+
+	return checkRowsAffected(result, "error trying to delete pack from database", "pack not found")
+}
+
+func scanPack(row rowScanner) (*entities.Pack, error) {
+	var pack entities.Pack
+
+	if err := row.Scan(&pack.Id, &pack.BeerId, &pack.Name, &pack.Size, &pack.Surcharge, &pack.Currency); err != nil {
+		return nil, err
+	}
+
+	return &pack, nil
+}
+
+func (r *sqlPackRepository) writeError(err error, pack entities.Pack, errorMessage string) *errors.RestError {
+	logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+	if r.db.dialect.IsDuplicateKey(err) {
+		return errors.NewConflictError(
+			fmt.Sprintf("beer %d already has a pack of %d", pack.BeerId, pack.Size))
+	}
+
+	return errors.NewInternalServerError(errorMessage)
+}
@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/go-sql-driver/mysql"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	queryListPacksByBeerTest      = "SELECT id, beer_id, name, size, surcharge, currency FROM pack WHERE beer_id = ? ORDER BY size ASC;"
+	queryGetPackByBeerAndSizeTest = "SELECT id, beer_id, name, size, surcharge, currency FROM pack WHERE beer_id = ? AND size = ?;"
+	queryInsertPackTest           = "INSERT INTO pack(beer_id, name, size, surcharge, currency) VALUES(?, ?, ?, ?, ?);"
+)
+
+var packColumns = []string{"id", "beer_id", "name", "size", "surcharge", "currency"}
+
+func Test_ListPacksByBeer_WhenQueryIsExecutedSuccessfully_ThenReturnPacks(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows(packColumns).
+		AddRow(1, 1, "6-pack", 6, "0.00", "COP").
+		AddRow(2, 1, "crate", 24, "3000.00", "COP")
+	mock.ExpectPrepare(queryListPacksByBeerTest)
+	mock.ExpectQuery(queryListPacksByBeerTest).WithArgs(int64(1)).WillReturnRows(queryRows)
+	repo := repository.NewMySqlPackRepository(db)
+
+	packs, err := repo.ListByBeer(1)
+
+	assert.Nil(t, err)
+	assert.Len(t, packs, 2)
+	assert.Equal(t, uint64(24), packs[1].Size)
+	assert.True(t, decimal.NewFromInt(3000).Equal(packs[1].Surcharge))
+}
+
+func Test_GetPackByBeerAndSize_WhenPackDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("pack not found")
+	mock.ExpectPrepare(queryGetPackByBeerAndSizeTest)
+	mock.ExpectQuery(queryGetPackByBeerAndSizeTest).WithArgs(int64(1), uint64(12)).WillReturnError(sql.ErrNoRows)
+	repo := repository.NewMySqlPackRepository(db)
+
+	pack, err := repo.GetByBeerAndSize(1, 12)
+
+	assert.Nil(t, pack)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_SavePack_WhenSizeAlreadyExists_ThenReturnConflictError(t *testing.T) {
+	pack := entities.Pack{BeerId: 1, Name: "12-pack", Size: 12, Surcharge: decimal.NewFromInt(1500), Currency: "COP"}
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewConflictError("beer 1 already has a pack of 12")
+	mock.ExpectPrepare(queryInsertPackTest)
+	mock.ExpectExec(queryInsertPackTest).
+		WithArgs(pack.BeerId, pack.Name, pack.Size, pack.Surcharge, pack.Currency).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	repo := repository.NewMySqlPackRepository(db)
+
+	packID, err := repo.Save(pack)
+
+	assert.Equal(t, int64(0), packID)
+	assert.Equal(t, expectedError, err)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const (
+	queryListPricingRules        = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule ORDER BY id ASC;"
+	queryListPricingRulesForBeer = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule WHERE beer_id IS NULL OR beer_id = ? ORDER BY id ASC;"
+	queryGetPricingRule          = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule WHERE id = ?;"
+	queryInsertPricingRule       = "INSERT INTO pricing_rule(beer_id, name, type, min_quantity, percentage, buy_quantity) VALUES(?, ?, ?, ?, ?, ?);"
+	queryUpdatePricingRule       = "UPDATE pricing_rule SET beer_id=?, name=?, type=?, min_quantity=?, percentage=?, buy_quantity=? WHERE id=?;"
+	queryDeletePricingRule       = "DELETE FROM pricing_rule WHERE id=?;"
+)
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type sqlPricingRuleRepository struct {
+	db *sqlClient
+}
+
+func NewSqlPricingRuleRepository(client *sql.DB, dialect db.Dialect) *sqlPricingRuleRepository {
+	return &sqlPricingRuleRepository{
+		db: newSqlClient(client, dialect),
+	}
+}
+
+func NewMySqlPricingRuleRepository(client *sql.DB) *sqlPricingRuleRepository {
+	return NewSqlPricingRuleRepository(client, db.MySQL)
+}
+
+func (r *sqlPricingRuleRepository) List() ([]entities.PricingRule, *errors.RestError) {
+	return r.list(queryListPricingRules)
+}
+
+func (r *sqlPricingRuleRepository) ListByBeer(beerID int64) ([]entities.PricingRule, *errors.RestError) {
+	return r.list(queryListPricingRulesForBeer, beerID)
+}
+
+func (r *sqlPricingRuleRepository) list(query string, args ...interface{}) ([]entities.PricingRule, *errors.RestError) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get pricing rules from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get pricing rules from database")
+	}
+	defer rows.Close()
+
+	rules := make([]entities.PricingRule, 0)
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get pricing rules from database")
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func (r *sqlPricingRuleRepository) GetByID(ruleID int64) (*entities.PricingRule, *errors.RestError) {
+	stmt, err := r.db.Prepare(queryGetPricingRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get pricing rule from database")
+	}
+	defer stmt.Close()
+
+	rule, getErr := scanPricingRule(stmt.QueryRow(ruleID))
+	if getErr != nil {
+		if genericerrors.Is(getErr, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("pricing rule not found")
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", getErr))
+		return nil, errors.NewInternalServerError("error trying to get pricing rule from database")
+	}
+
+	return rule, nil
+}
+
+func (r *sqlPricingRuleRepository) Save(rule entities.PricingRule) (int64, *errors.RestError) {
+	stmt, err := r.db.PrepareInsert(queryInsertPricingRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save pricing rule in database")
+	}
+	defer stmt.Close()
+
+	insertedID, saveErr := r.db.insert(stmt, nullableBeerID(rule.BeerId), rule.Name, rule.Type, rule.MinQuantity, rule.Percentage, rule.BuyQuantity)
+	if saveErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", saveErr))
+		return 0, errors.NewInternalServerError("error trying to save pricing rule in database")
+	}
+
+	return insertedID, nil
+}
+
+func (r *sqlPricingRuleRepository) Update(rule entities.PricingRule) *errors.RestError {
+	stmt, err := r.db.Prepare(queryUpdatePricingRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to update pricing rule in database")
+	}
+	defer stmt.Close()
+
+	result, updateErr := stmt.Exec(nullableBeerID(rule.BeerId), rule.Name, rule.Type, rule.MinQuantity, rule.Percentage, rule.BuyQuantity, rule.Id)
+	if updateErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", updateErr))
+		return errors.NewInternalServerError("error trying to update pricing rule in database")
+	}
+
+	return checkRowsAffected(result, "error trying to update pricing rule in database", "pricing rule not found")
+}
+
+func (r *sqlPricingRuleRepository) Delete(ruleID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryDeletePricingRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to delete pricing rule from database")
+	}
+	defer stmt.Close()
+
+	result, deleteErr := stmt.Exec(ruleID)
+	if deleteErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", deleteErr))
+		return errors.NewInternalServerError("error trying to delete pricing rule from database")
+	}
+
+	return checkRowsAffected(result, "error trying to delete pricing rule from database", "pricing rule not found")
+}
+
+func scanPricingRule(row rowScanner) (*entities.PricingRule, error) {
+	var rule entities.PricingRule
+	var beerID sql.NullInt64
+
+	if err := row.Scan(&rule.Id, &beerID, &rule.Name, &rule.Type, &rule.MinQuantity, &rule.Percentage, &rule.BuyQuantity); err != nil {
+		return nil, err
+	}
+
+	if beerID.Valid {
+		rule.BeerId = &beerID.Int64
+	}
+
+	return &rule, nil
+}
+
+func nullableBeerID(beerID *int64) sql.NullInt64 {
+	if beerID == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: *beerID, Valid: true}
+}
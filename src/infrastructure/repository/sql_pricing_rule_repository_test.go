@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	queryListPricingRulesTest        = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule ORDER BY id ASC;"
+	queryListPricingRulesForBeerTest = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule WHERE beer_id IS NULL OR beer_id = ? ORDER BY id ASC;"
+	queryGetPricingRuleTest          = "SELECT id, beer_id, name, type, min_quantity, percentage, buy_quantity FROM pricing_rule WHERE id = ?;"
+	queryInsertPricingRuleTest       = "INSERT INTO pricing_rule(beer_id, name, type, min_quantity, percentage, buy_quantity) VALUES(?, ?, ?, ?, ?, ?);"
+	queryUpdatePricingRuleTest       = "UPDATE pricing_rule SET beer_id=?, name=?, type=?, min_quantity=?, percentage=?, buy_quantity=? WHERE id=?;"
+	queryDeletePricingRuleTest       = "DELETE FROM pricing_rule WHERE id=?;"
+)
+
+var pricingRuleColumns = []string{"id", "beer_id", "name", "type", "min_quantity", "percentage", "buy_quantity"}
+
+func Test_ListPricingRules_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewInternalServerError("error trying to get pricing rules from database")
+	mock.ExpectPrepare(queryListPricingRulesTest)
+	mock.ExpectQuery(queryListPricingRulesTest).WillReturnError(genericerrors.New("some error"))
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	rules, err := repo.List()
+
+	assert.Nil(t, rules)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ListPricingRulesByBeer_WhenQueryIsExecutedSuccessfully_ThenReturnBeerAndGlobalRules(t *testing.T) {
+	beerID := int64(1)
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows(pricingRuleColumns).
+		AddRow(1, nil, "global", entities.BuyNGetOnePricingRule, 0, "0.00", 5).
+		AddRow(2, beerID, "volume", entities.PercentagePricingRule, 12, "5.00", 0)
+	mock.ExpectPrepare(queryListPricingRulesForBeerTest)
+	mock.ExpectQuery(queryListPricingRulesForBeerTest).WithArgs(beerID).WillReturnRows(queryRows)
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	rules, err := repo.ListByBeer(beerID)
+
+	assert.Nil(t, err)
+	assert.Len(t, rules, 2)
+	assert.Nil(t, rules[0].BeerId)
+	assert.Equal(t, uint64(5), rules[0].BuyQuantity)
+	assert.Equal(t, beerID, *rules[1].BeerId)
+	assert.True(t, decimal.NewFromInt(5).Equal(rules[1].Percentage))
+}
+
+func Test_GetPricingRuleByID_WhenRuleDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("pricing rule not found")
+	mock.ExpectPrepare(queryGetPricingRuleTest)
+	mock.ExpectQuery(queryGetPricingRuleTest).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	rule, err := repo.GetByID(1)
+
+	assert.Nil(t, rule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_SavePricingRule_WhenRuleIsGlobal_ThenInsertNullBeerId(t *testing.T) {
+	rule := givenGlobalPricingRule()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryInsertPricingRuleTest)
+	mock.ExpectExec(queryInsertPricingRuleTest).
+		WithArgs(sql.NullInt64{}, rule.Name, rule.Type, rule.MinQuantity, rule.Percentage, rule.BuyQuantity).
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	ruleID, err := repo.Save(rule)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), ruleID)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdatePricingRule_WhenNoRowIsAffected_ThenReturnNotFoundError(t *testing.T) {
+	rule := givenGlobalPricingRule()
+	rule.Id = 3
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("pricing rule not found")
+	mock.ExpectPrepare(queryUpdatePricingRuleTest)
+	mock.ExpectExec(queryUpdatePricingRuleTest).
+		WithArgs(sql.NullInt64{}, rule.Name, rule.Type, rule.MinQuantity, rule.Percentage, rule.BuyQuantity, rule.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	err := repo.Update(rule)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_DeletePricingRule_WhenQueryIsExecutedSuccessfully_ThenReturnNil(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryDeletePricingRuleTest)
+	mock.ExpectExec(queryDeletePricingRuleTest).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	repo := repository.NewMySqlPricingRuleRepository(db)
+
+	err := repo.Delete(3)
+
+	assert.Nil(t, err)
+}
+
+func givenGlobalPricingRule() entities.PricingRule {
+	return entities.PricingRule{
+		Name:        "promo",
+		Type:        entities.BuyNGetOnePricingRule,
+		BuyQuantity: 5,
+	}
+}
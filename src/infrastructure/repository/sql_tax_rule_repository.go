@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"fmt"
+
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/logger"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+)
+
+const (
+	queryListTaxRules          = "SELECT id, country, category, name, type, percentage, amount, currency FROM tax_rule ORDER BY id ASC;"
+	queryListTaxRulesByCountry = "SELECT id, country, category, name, type, percentage, amount, currency FROM tax_rule WHERE country = ? ORDER BY id ASC;"
+	queryGetTaxRule            = "SELECT id, country, category, name, type, percentage, amount, currency FROM tax_rule WHERE id = ?;"
+	queryInsertTaxRule         = "INSERT INTO tax_rule(country, category, name, type, percentage, amount, currency) VALUES(?, ?, ?, ?, ?, ?, ?);"
+	queryUpdateTaxRule         = "UPDATE tax_rule SET country=?, category=?, name=?, type=?, percentage=?, amount=?, currency=? WHERE id=?;"
+	queryDeleteTaxRule         = "DELETE FROM tax_rule WHERE id=?;"
+)
+
+type sqlTaxRuleRepository struct {
+	db *sqlClient
+}
+
+func NewSqlTaxRuleRepository(client *sql.DB, dialect db.Dialect) *sqlTaxRuleRepository {
+	return &sqlTaxRuleRepository{
+		db: newSqlClient(client, dialect),
+	}
+}
+
+func NewMySqlTaxRuleRepository(client *sql.DB) *sqlTaxRuleRepository {
+	return NewSqlTaxRuleRepository(client, db.MySQL)
+}
+
+func (r *sqlTaxRuleRepository) List() ([]entities.TaxRule, *errors.RestError) {
+	return r.list(queryListTaxRules)
+}
+
+func (r *sqlTaxRuleRepository) ListByCountry(country string) ([]entities.TaxRule, *errors.RestError) {
+	return r.list(queryListTaxRulesByCountry, country)
+}
+
+func (r *sqlTaxRuleRepository) list(query string, args ...interface{}) ([]entities.TaxRule, *errors.RestError) {
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get tax rules from database")
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get tax rules from database")
+	}
+	defer rows.Close()
+
+	rules := make([]entities.TaxRule, 0)
+	for rows.Next() {
+		rule, err := scanTaxRule(rows)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("error trying to scan rows: %s", err))
+			return nil, errors.NewInternalServerError("error trying to get tax rules from database")
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func (r *sqlTaxRuleRepository) GetByID(ruleID int64) (*entities.TaxRule, *errors.RestError) {
+	stmt, err := r.db.Prepare(queryGetTaxRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return nil, errors.NewInternalServerError("error trying to get tax rule from database")
+	}
+	defer stmt.Close()
+
+	rule, getErr := scanTaxRule(stmt.QueryRow(ruleID))
+	if getErr != nil {
+		if genericerrors.Is(getErr, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("tax rule not found")
+		}
+
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", getErr))
+		return nil, errors.NewInternalServerError("error trying to get tax rule from database")
+	}
+
+	return rule, nil
+}
+
+func (r *sqlTaxRuleRepository) Save(rule entities.TaxRule) (int64, *errors.RestError) {
+	stmt, err := r.db.PrepareInsert(queryInsertTaxRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return 0, errors.NewInternalServerError("error trying to save tax rule in database")
+	}
+	defer stmt.Close()
+
+	insertedID, saveErr := r.db.insert(stmt, rule.Country, rule.Category, rule.Name, rule.Type, rule.Percentage, rule.Amount, rule.Currency)
+	if saveErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", saveErr))
+		return 0, errors.NewInternalServerError("error trying to save tax rule in database")
+	}
+
+	return insertedID, nil
+}
+
+func (r *sqlTaxRuleRepository) Update(rule entities.TaxRule) *errors.RestError {
+	stmt, err := r.db.Prepare(queryUpdateTaxRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to update tax rule in database")
+	}
+	defer stmt.Close()
+
+	result, updateErr := stmt.Exec(rule.Country, rule.Category, rule.Name, rule.Type, rule.Percentage, rule.Amount, rule.Currency, rule.Id)
+	if updateErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", updateErr))
+		return errors.NewInternalServerError("error trying to update tax rule in database")
+	}
+
+	return checkRowsAffected(result, "error trying to update tax rule in database", "tax rule not found")
+}
+
+func (r *sqlTaxRuleRepository) Delete(ruleID int64) *errors.RestError {
+	stmt, err := r.db.Prepare(queryDeleteTaxRule)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to prepare statement: %s", err))
+		return errors.NewInternalServerError("error trying to delete tax rule from database")
+	}
+	defer stmt.Close()
+
+	result, deleteErr := stmt.Exec(ruleID)
+	if deleteErr != nil {
+		logger.Log.Error(fmt.Sprintf("error trying to execute query: %s", deleteErr))
+		return errors.NewInternalServerError("error trying to delete tax rule from database")
+	}
+
+	return checkRowsAffected(result, "error trying to delete tax rule from database", "tax rule not found")
+}
+
+func scanTaxRule(row rowScanner) (*entities.TaxRule, error) {
+	var rule entities.TaxRule
+
+	if err := row.Scan(&rule.Id, &rule.Country, &rule.Category, &rule.Name, &rule.Type, &rule.Percentage, &rule.Amount, &rule.Currency); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"database/sql"
+	genericerrors "errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	queryListTaxRulesByCountryTest = "SELECT id, country, category, name, type, percentage, amount, currency FROM tax_rule WHERE country = ? ORDER BY id ASC;"
+	queryGetTaxRuleTest            = "SELECT id, country, category, name, type, percentage, amount, currency FROM tax_rule WHERE id = ?;"
+	queryInsertTaxRuleTest         = "INSERT INTO tax_rule(country, category, name, type, percentage, amount, currency) VALUES(?, ?, ?, ?, ?, ?, ?);"
+	queryUpdateTaxRuleTest         = "UPDATE tax_rule SET country=?, category=?, name=?, type=?, percentage=?, amount=?, currency=? WHERE id=?;"
+)
+
+var taxRuleColumns = []string{"id", "country", "category", "name", "type", "percentage", "amount", "currency"}
+
+func Test_ListTaxRulesByCountry_WhenExecuteQueryFail_ThenReturnError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewInternalServerError("error trying to get tax rules from database")
+	mock.ExpectPrepare(queryListTaxRulesByCountryTest)
+	mock.ExpectQuery(queryListTaxRulesByCountryTest).WithArgs("DE").WillReturnError(genericerrors.New("some error"))
+	repo := repository.NewMySqlTaxRuleRepository(db)
+
+	rules, err := repo.ListByCountry("DE")
+
+	assert.Nil(t, rules)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_ListTaxRulesByCountry_WhenQueryIsExecutedSuccessfully_ThenReturnRules(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	queryRows := mock.NewRows(taxRuleColumns).
+		AddRow(1, "DE", entities.GeneralTaxCategory, "VAT", entities.PercentageTax, "19.00", "0.0000", "").
+		AddRow(2, "DE", entities.BeerTaxCategory, "Biersteuer", entities.PerUnitTax, "0.00", "0.0500", "EUR")
+	mock.ExpectPrepare(queryListTaxRulesByCountryTest)
+	mock.ExpectQuery(queryListTaxRulesByCountryTest).WithArgs("DE").WillReturnRows(queryRows)
+	repo := repository.NewMySqlTaxRuleRepository(db)
+
+	rules, err := repo.ListByCountry("DE")
+
+	assert.Nil(t, err)
+	assert.Len(t, rules, 2)
+	assert.True(t, decimal.NewFromInt(19).Equal(rules[0].Percentage))
+	assert.Equal(t, entities.PerUnitTax, rules[1].Type)
+	assert.True(t, decimal.RequireFromString("0.05").Equal(rules[1].Amount))
+	assert.Equal(t, "EUR", rules[1].Currency)
+}
+
+func Test_GetTaxRuleByID_WhenRuleDoesNotExist_ThenReturnNotFoundError(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("tax rule not found")
+	mock.ExpectPrepare(queryGetTaxRuleTest)
+	mock.ExpectQuery(queryGetTaxRuleTest).WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+	repo := repository.NewMySqlTaxRuleRepository(db)
+
+	rule, err := repo.GetByID(1)
+
+	assert.Nil(t, rule)
+	assert.Equal(t, expectedError, err)
+}
+
+func Test_SaveTaxRule_WhenQueryIsExecutedSuccessfully_ThenReturnInsertedID(t *testing.T) {
+	rule := givenVATRule()
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	mock.ExpectPrepare(queryInsertTaxRuleTest)
+	mock.ExpectExec(queryInsertTaxRuleTest).
+		WithArgs(rule.Country, rule.Category, rule.Name, rule.Type, rule.Percentage, rule.Amount, rule.Currency).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+	repo := repository.NewMySqlTaxRuleRepository(db)
+
+	ruleID, err := repo.Save(rule)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), ruleID)
+}
+
+func Test_UpdateTaxRule_WhenNoRowIsAffected_ThenReturnNotFoundError(t *testing.T) {
+	rule := givenVATRule()
+	rule.Id = 4
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	expectedError := errors.NewNotFoundError("tax rule not found")
+	mock.ExpectPrepare(queryUpdateTaxRuleTest)
+	mock.ExpectExec(queryUpdateTaxRuleTest).
+		WithArgs(rule.Country, rule.Category, rule.Name, rule.Type, rule.Percentage, rule.Amount, rule.Currency, rule.Id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	repo := repository.NewMySqlTaxRuleRepository(db)
+
+	err := repo.Update(rule)
+
+	assert.Equal(t, expectedError, err)
+}
+
+func givenVATRule() entities.TaxRule {
+	return entities.TaxRule{
+		Country:    "DE",
+		Category:   entities.GeneralTaxCategory,
+		Name:       "VAT",
+		Type:       entities.PercentageTax,
+		Percentage: decimal.NewFromInt(19),
+	}
+}
@@ -0,0 +1,99 @@
+package repository_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/dleonsal/beers-api/src/configs"
+	"github.com/dleonsal/beers-api/src/core/domain/entities"
+	"github.com/dleonsal/beers-api/src/errors"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository"
+	"github.com/dleonsal/beers-api/src/infrastructure/repository/db"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SQLiteSave_WhenBeerIdExists_ThenReturnConflictError(t *testing.T) {
+	repo := repository.NewSqlBeerRepository(givenSQLiteDB(t), db.SQLite)
+	repo.Save(*givenBeer())
+
+	id, err := repo.Save(*givenBeer())
+
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, errors.NewConflictError("beer id 1 already exists"), err)
+}
+
+func Test_SQLiteSave_WhenBeerHasNoId_ThenReturnGeneratedId(t *testing.T) {
+	repo := repository.NewSqlBeerRepository(givenSQLiteDB(t), db.SQLite)
+	beer := givenBeer()
+	beer.Id = 7
+	repo.Save(*beer)
+	beer.Id = 0
+
+	id, err := repo.Save(*beer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), id)
+	saved, _ := repo.GetByID(8)
+	assert.Equal(t, "Pilsen", saved.Name)
+	assert.True(t, decimal.NewFromInt(2500).Equal(saved.Price))
+}
+
+func Test_SQLiteList_WhenFilterHasDifferentCase_ThenReturnMatchingBeers(t *testing.T) {
+	repo := repository.NewSqlBeerRepository(givenSQLiteDB(t), db.SQLite)
+	repo.Save(entities.Beer{Id: 1, Name: "Pilsen", Brewery: "Bavaria", Country: "Colombia", Price: decimal.NewFromInt(2500), Currency: "COP"})
+	repo.Save(entities.Beer{Id: 2, Name: "Corona", Brewery: "Modelo", Country: "Mexico", Price: decimal.NewFromInt(2), Currency: "USD"})
+	query := entities.NewBeerQuery()
+	query.Country = "colombia"
+
+	beers, err := repo.List(query)
+
+	assert.Nil(t, err)
+	assert.Len(t, beers, 1)
+	assert.Equal(t, int64(1), beers[0].Id)
+}
+
+func Test_SQLiteDelete_WhenBeerHasPacks_ThenDeletePacks(t *testing.T) {
+	client := givenSQLiteDB(t)
+	beerRepo := repository.NewSqlBeerRepository(client, db.SQLite)
+	packRepo := repository.NewSqlPackRepository(client, db.SQLite)
+	beerRepo.Save(*givenBeer())
+	packID, _ := packRepo.Save(entities.Pack{BeerId: 1, Name: "Six pack", Size: 6, Surcharge: decimal.NewFromInt(500), Currency: "COP"})
+
+	err := beerRepo.Delete(1)
+
+	assert.Nil(t, err)
+	pack, packErr := packRepo.GetByID(packID)
+	assert.Nil(t, pack)
+	assert.Equal(t, errors.NewNotFoundError("pack not found"), packErr)
+}
+
+func Test_SQLiteSaveBatch_WhenBeerInTheMiddleFail_ThenSaveTheOthers(t *testing.T) {
+	repo := repository.NewSqlBeerRepository(givenSQLiteDB(t), db.SQLite)
+	lastBeer := givenBeer()
+	lastBeer.Id = 3
+
+	results, err := repo.SaveBatch([]entities.Beer{*givenBeer(), *givenBeer(), *lastBeer}, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []entities.BeerSaveResult{
+		{Id: 1},
+		{Err: errors.NewConflictError("beer id 1 already exists")},
+		{Id: 3},
+	}, results)
+	saved, _ := repo.GetByID(3)
+	assert.Equal(t, int64(3), saved.Id)
+}
+
+func givenSQLiteDB(t *testing.T) *sql.DB {
+	client, err := db.NewSqlDB(&configs.DBConfig{DriverName: configs.SQLiteDriverName, DBName: t.TempDir() + "/beers.db"}, db.SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	migrations, _ := db.EmbeddedMigrations(db.SQLite)
+	if _, err := db.NewMigrator(client, db.SQLite, migrations).Up(); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
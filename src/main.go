@@ -1,7 +1,24 @@
 package main
 
-import "github.com/dleonsal/beers-api/src/app"
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dleonsal/beers-api/src/app"
+)
 
 func main() {
-	app.StartApplication()
+	configPath := flag.String("config", "", "path of the YAML config file, instead of the one of APP_ENV")
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) > 0 && args[0] == "migrate" {
+		os.Exit(app.RunMigrate(*configPath, args[1:]))
+	}
+
+	if err := app.StartApplication(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }